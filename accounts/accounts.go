@@ -1,6 +1,7 @@
 package accounts
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/darrenvechain/thorgo/client"
@@ -10,49 +11,69 @@ import (
 )
 
 type Visitor struct {
-	client   *client.Client
+	client   client.Backend
 	account  common.Address
-	revision *common.Hash
+	revision *client.Revision
 }
 
-func New(c *client.Client, account common.Address) *Visitor {
+func New(c client.Backend, account common.Address) *Visitor {
 	return &Visitor{client: c, account: account}
 }
 
-// Revision sets the optional revision for the API calls.
-func (a *Visitor) Revision(revision common.Hash) *Visitor {
+// Revision sets the optional revision for the API calls, for example client.RevisionNumber(100).
+func (a *Visitor) Revision(revision client.Revision) *Visitor {
 	a.revision = &revision
 	return a
 }
 
 // Get fetches the account information for the given address.
 func (a *Visitor) Get() (*client.Account, error) {
+	return a.GetWithContext(context.Background())
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (a *Visitor) GetWithContext(ctx context.Context) (*client.Account, error) {
 	if a.revision == nil {
-		return a.client.Account(a.account)
+		return a.client.AccountWithContext(ctx, a.account)
 	}
-	return a.client.AccountAt(a.account, *a.revision)
+	return a.client.AccountAtWithContext(ctx, a.account, *a.revision)
 }
 
 // Code fetches the byte code of the contract at the given address.
 func (a *Visitor) Code() (*client.AccountCode, error) {
+	return a.CodeWithContext(context.Background())
+}
+
+// CodeWithContext is like Code but uses the given context for the request.
+func (a *Visitor) CodeWithContext(ctx context.Context) (*client.AccountCode, error) {
 	if a.revision == nil {
-		return a.client.AccountCode(a.account)
+		return a.client.AccountCodeWithContext(ctx, a.account)
 	}
 
-	return a.client.AccountCodeAt(a.account, *a.revision)
+	return a.client.AccountCodeAtWithContext(ctx, a.account, *a.revision)
 }
 
 // Storage fetches the storage value for the given key.
 func (a *Visitor) Storage(key common.Hash) (*client.AccountStorage, error) {
+	return a.StorageWithContext(context.Background(), key)
+}
+
+// StorageWithContext is like Storage but uses the given context for the request.
+func (a *Visitor) StorageWithContext(ctx context.Context, key common.Hash) (*client.AccountStorage, error) {
 	if a.revision == nil {
-		return a.client.AccountStorage(a.account, key)
+		return a.client.AccountStorageWithContext(ctx, a.account, key)
 	}
 
-	return a.client.AccountStorageAt(a.account, key, *a.revision)
+	return a.client.AccountStorageAtWithContext(ctx, a.account, key, *a.revision)
 }
 
 // Call executes a read-only contract call.
 func (a *Visitor) Call(calldata []byte) (*client.InspectResponse, error) {
+	return a.CallWithContext(context.Background(), calldata)
+}
+
+// CallWithContext is like Call but uses the given context for the request.
+func (a *Visitor) CallWithContext(ctx context.Context, calldata []byte) (*client.InspectResponse, error) {
 	clause := tx.NewClause(&a.account).WithData(calldata).WithValue(big.NewInt(0))
 
 	request := client.InspectRequest{
@@ -64,9 +85,9 @@ func (a *Visitor) Call(calldata []byte) (*client.InspectResponse, error) {
 	)
 
 	if a.revision == nil {
-		inspection, err = a.client.Inspect(request)
+		inspection, err = a.client.InspectWithContext(ctx, request)
 	} else {
-		inspection, err = a.client.InspectAt(request, *a.revision)
+		inspection, err = a.client.InspectAtWithContext(ctx, request, *a.revision)
 	}
 
 	if err != nil {
@@ -78,5 +99,8 @@ func (a *Visitor) Call(calldata []byte) (*client.InspectResponse, error) {
 
 // Contract returns a new Contract instance.
 func (a *Visitor) Contract(abi *abi.ABI) *Contract {
-	return NewContractAt(a.client, a.account, abi, a.revision)
+	if a.revision == nil {
+		return NewContract(a.client, a.account, abi)
+	}
+	return NewContractAt(a.client, a.account, abi, *a.revision)
 }
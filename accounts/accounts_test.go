@@ -36,7 +36,7 @@ func TestGetAccount(t *testing.T) {
 // TestGetAccountForRevision fetches a thor solo account for the genesis block
 // and checks if the balance and energy are greater than 0
 func TestGetAccountForRevision(t *testing.T) {
-	acc, err := accounts.New(thorClient, account1.Address()).Revision(solo.GenesisID()).Get()
+	acc, err := accounts.New(thorClient, account1.Address()).Revision(client.RevisionID(solo.GenesisID())).Get()
 
 	assert.NoError(t, err, "Account.httpGet should not return an error")
 	assert.NotNil(t, acc, "Account.httpGet should return an account")
@@ -57,7 +57,7 @@ func TestGetCode(t *testing.T) {
 
 // TestGetCodeForRevision fetches the code of the VTHO contract for the genesis block
 func TestGetCodeForRevision(t *testing.T) {
-	vtho, err := accounts.New(thorClient, vtho.Address).Revision(solo.GenesisID()).Code()
+	vtho, err := accounts.New(thorClient, vtho.Address).Revision(client.RevisionID(solo.GenesisID())).Code()
 
 	assert.NoError(t, err, "Account.Code should not return an error")
 	assert.NotNil(t, vtho, "Account.Code should return a code")
@@ -75,7 +75,7 @@ func TestGetStorage(t *testing.T) {
 
 // TestGetStorageForRevision fetches a storage position of the VTHO contract for the genesis block
 func TestGetStorageForRevision(t *testing.T) {
-	storage, err := accounts.New(thorClient, vtho.Address).Revision(solo.GenesisID()).Storage(common.Hash{})
+	storage, err := accounts.New(thorClient, vtho.Address).Revision(client.RevisionID(solo.GenesisID())).Storage(common.Hash{})
 
 	assert.NoError(t, err, "Account.Storage should not return an error")
 	assert.NotNil(t, storage, "Account.Storage should return a storage")
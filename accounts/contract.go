@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -16,15 +17,15 @@ import (
 
 // Contract represents a smart contract on the blockchain.
 type Contract struct {
-	client   *client.Client
-	revision *common.Hash
+	client   client.Backend
+	revision *client.Revision
 	ABI      *abi.ABI
 	Address  common.Address
 }
 
 // NewContract creates a new contract instance.
 func NewContract(
-	client *client.Client,
+	client client.Backend,
 	address common.Address,
 	abi *abi.ABI,
 ) *Contract {
@@ -33,16 +34,26 @@ func NewContract(
 
 // NewContractAt creates a new contract instance at a specific revision. It should be used to query historical contract states.
 func NewContractAt(
-	client *client.Client,
+	client client.Backend,
 	address common.Address,
 	abi *abi.ABI,
-	revision *common.Hash,
+	revision client.Revision,
 ) *Contract {
-	return &Contract{client: client, Address: address, ABI: abi, revision: revision}
+	return &Contract{client: client, Address: address, ABI: abi, revision: &revision}
+}
+
+// At returns a copy of the contract whose calls are answered at the given revision.
+func (c *Contract) At(revision client.Revision) *Contract {
+	return NewContractAt(c.client, c.Address, c.ABI, revision)
 }
 
 // Call executes a read-only contract call.
 func (c *Contract) Call(method string, value interface{}, args ...interface{}) error {
+	return c.CallWithContext(context.Background(), method, value, args...)
+}
+
+// CallWithContext is like Call but uses the given context for the request.
+func (c *Contract) CallWithContext(ctx context.Context, method string, value interface{}, args ...interface{}) error {
 	packed, err := c.ABI.Pack(method, args...)
 	if err != nil {
 		return fmt.Errorf("failed to pack method %s: %w", method, err)
@@ -51,25 +62,13 @@ func (c *Contract) Call(method string, value interface{}, args ...interface{}) e
 	request := client.InspectRequest{
 		Clauses: []*tx.Clause{clause},
 	}
-	var response []client.InspectResponse
-	if c.revision == nil {
-		response, err = c.client.Inspect(request)
-	} else {
-		response, err = c.client.InspectAt(request, *c.revision)
-	}
+	response, err := inspect(ctx, c.client, request, c.revision)
 	if err != nil {
 		return fmt.Errorf("failed to inspect contract: %w", err)
 	}
-	inspection := response[0]
-	if inspection.Reverted {
-		return errors.New("contract call reverted")
-	}
-	if inspection.VmError != "" {
-		return errors.New(inspection.VmError)
-	}
-	decoded, err := hexutil.Decode(inspection.Data)
+	decoded, err := inspectionOutput(response[0])
 	if err != nil {
-		return fmt.Errorf("failed to decode data: %w", err)
+		return err
 	}
 	err = c.ABI.UnpackIntoInterface(value, method, decoded)
 	if err != nil {
@@ -115,13 +114,38 @@ type TxManager interface {
 	SendClauses(clauses []*tx.Clause) (common.Hash, error)
 }
 
+// ContextTxManager is a TxManager that can stop sending clauses when a context is done.
+// Managers implementing it are preferred by the WithContext methods of this package.
+type ContextTxManager interface {
+	TxManager
+	SendClausesWithContext(ctx context.Context, clauses []*tx.Clause) (common.Hash, error)
+}
+
+// sendClauses uses the context-aware method of the manager when it is available.
+func sendClauses(ctx context.Context, manager TxManager, clauses []*tx.Clause) (common.Hash, error) {
+	if m, ok := manager.(ContextTxManager); ok {
+		return m.SendClausesWithContext(ctx, clauses)
+	}
+	return manager.SendClauses(clauses)
+}
+
 // Send executes a transaction with a single clause.
 func (c *Contract) Send(manager TxManager, method string, args ...interface{}) (*transactions.Visitor, error) {
+	return c.SendWithContext(context.Background(), manager, method, args...)
+}
+
+// SendWithContext is like Send but passes the context to the manager if it implements ContextTxManager.
+func (c *Contract) SendWithContext(
+	ctx context.Context,
+	manager TxManager,
+	method string,
+	args ...interface{},
+) (*transactions.Visitor, error) {
 	clause, err := c.AsClause(method, args...)
 	if err != nil {
 		return &transactions.Visitor{}, fmt.Errorf("failed to pack method %s: %w", method, err)
 	}
-	txId, err := manager.SendClauses([]*tx.Clause{clause})
+	txId, err := sendClauses(ctx, manager, []*tx.Clause{clause})
 	if err != nil {
 		return &transactions.Visitor{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
@@ -184,6 +208,9 @@ func (c *Contract) EventCriteria(name string, matchers ...interface{}) (client.E
 	return criteria, nil
 }
 
+// ErrEventMismatch is returned when a log was not emitted by the event it is decoded as.
+var ErrEventMismatch = errors.New("log does not match the event")
+
 type Event struct {
 	Name string
 	Args map[string]interface{}
@@ -209,46 +236,136 @@ type Event struct {
 //	  fmt.Println(event.Name, event.Args)
 //	}
 //
-// This function returns a slice of decoded event objects and any error encountered.
+// Indexed arguments of dynamic types, such as strings, are decoded as the common.Hash stored in the topic.
+// The events are found by their signature, so anonymous events must be decoded by name with UnpackEvent.
+// An error is returned for the first log which can't be decoded.
 func (c *Contract) DecodeEvents(logs []client.EventLog) ([]Event, error) {
-	var decoded []Event
-	for _, log := range logs {
-		if len(log.Topics) < 2 {
-			continue
+	decoded := make([]Event, 0, len(logs))
+	for i, log := range logs {
+		if len(log.Topics) == 0 {
+			return nil, fmt.Errorf("log %d of tx %s: no event signature, decode anonymous events with UnpackEvent",
+				i, log.Meta.TxID)
 		}
-
 		eventABI, err := c.ABI.EventByID(log.Topics[0])
 		if err != nil {
-			continue
-		}
-
-		var indexed abi.Arguments
-		for _, arg := range eventABI.Inputs {
-			if arg.Indexed {
-				indexed = append(indexed, arg)
-			}
+			return nil, fmt.Errorf("log %d of tx %s: %w", i, log.Meta.TxID, err)
 		}
-
-		values := make(map[string]interface{})
-		err = abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:])
+		event, err := decodeEvent(eventABI, log)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("log %d of tx %s: %w", i, log.Meta.TxID, err)
 		}
+		decoded = append(decoded, event)
+	}
+	return decoded, nil
+}
 
-		data, err := hexutil.Decode(log.Data)
+// decodeEvent decodes a log emitted by the event.
+func decodeEvent(eventABI *abi.Event, log client.EventLog) (Event, error) {
+	topics, data, err := eventLog(eventABI, log)
+	if err != nil {
+		return Event{}, err
+	}
+	values := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(values, indexedInputs(eventABI), topics); err != nil {
+		return Event{}, err
+	}
+	if err := eventABI.Inputs.UnpackIntoMap(values, data); err != nil {
+		return Event{}, err
+	}
+	return Event{Name: eventABI.Name, Args: values, Log: log}, nil
+}
+
+// UnpackEvent decodes a log of the named event into out, a pointer to a struct with a field per input of the
+// event, named after the input in camel case. For example, the event
+//
+//	event Transfer(address indexed from, address indexed to, uint256 value);
+//
+// is decoded into:
+//
+//	var transfer struct {
+//	  From  common.Address
+//	  To    common.Address
+//	  Value *big.Int
+//	}
+//	err := contract.UnpackEvent(&transfer, "Transfer", log)
+//
+// Anonymous events and events without indexed inputs are supported. Indexed inputs of dynamic types, such as
+// strings, bytes and arrays, are only stored as their hash in the topics, so their field must be a common.Hash.
+// ErrEventMismatch is returned if the log wasn't emitted by the event.
+func (c *Contract) UnpackEvent(out interface{}, name string, log client.EventLog) error {
+	eventABI, ok := c.ABI.Events[name]
+	if !ok {
+		return fmt.Errorf("event %s not found", name)
+	}
+	topics, data, err := eventLog(&eventABI, log)
+	if err != nil {
+		return err
+	}
+
+	values, err := eventABI.Inputs.Unpack(data)
+	if err != nil {
+		return fmt.Errorf("failed to unpack event %s: %w", name, err)
+	}
+	if err := eventABI.Inputs.Copy(out, values); err != nil {
+		return fmt.Errorf("failed to unpack event %s: %w", name, err)
+	}
+	if err := abi.ParseTopics(out, indexedInputs(&eventABI), topics); err != nil {
+		return fmt.Errorf("failed to unpack the topics of event %s: %w", name, err)
+	}
+	return nil
+}
+
+// DecodeEvent decodes a log of the named event into a value of type T, a struct as described in
+// Contract.UnpackEvent.
+//
+//	transfer, err := accounts.DecodeEvent[Transfer](contract, "Transfer", log)
+func DecodeEvent[T any](c *Contract, name string, log client.EventLog) (T, error) {
+	var out T
+	err := c.UnpackEvent(&out, name, log)
+	return out, err
+}
+
+// DecodeEventLogs decodes logs of the named event into values of type T, stopping at the first log which can't be
+// decoded.
+func DecodeEventLogs[T any](c *Contract, name string, logs []client.EventLog) ([]T, error) {
+	decoded := make([]T, 0, len(logs))
+	for i, log := range logs {
+		out, err := DecodeEvent[T](c, name, log)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("log %d of tx %s: %w", i, log.Meta.TxID, err)
 		}
-		err = eventABI.Inputs.UnpackIntoMap(values, data)
-		if err != nil {
-			return nil, err
+		decoded = append(decoded, out)
+	}
+	return decoded, nil
+}
+
+// eventLog checks that the log was emitted by the event, and returns the topics of its indexed inputs and the
+// data of the other inputs.
+func eventLog(event *abi.Event, log client.EventLog) ([]common.Hash, []byte, error) {
+	topics := log.Topics
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return nil, nil, fmt.Errorf("%w: not a %s event", ErrEventMismatch, event.Name)
 		}
+		topics = topics[1:]
+	}
+	if indexed := len(indexedInputs(event)); len(topics) != indexed {
+		return nil, nil, fmt.Errorf("%w: %s has %d indexed inputs but the log has %d topics",
+			ErrEventMismatch, event.Name, indexed, len(topics))
+	}
+	data, err := hexutil.Decode(log.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+	return topics, data, nil
+}
 
-		decoded = append(decoded, Event{
-			Name: eventABI.Name,
-			Args: values,
-			Log:  log,
-		})
+func indexedInputs(event *abi.Event) abi.Arguments {
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
 	}
-	return decoded, nil
+	return indexed
 }
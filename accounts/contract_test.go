@@ -2,12 +2,17 @@ package accounts_test
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/darrenvechain/thorgo/accounts"
 	"github.com/darrenvechain/thorgo/client"
 	"github.com/darrenvechain/thorgo/events"
 	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,3 +100,105 @@ func TestContract_EventCriteria(t *testing.T) {
 	assert.IsType(t, common.Address{}, ev.Args["to"])
 	assert.IsType(t, &big.Int{}, ev.Args["value"])
 }
+
+const eventsABI = `[
+	{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Ping","inputs":[
+		{"name":"value","type":"uint256","indexed":false},
+		{"name":"note","type":"string","indexed":false}]},
+	{"type":"event","name":"Anon","anonymous":true,"inputs":[
+		{"name":"who","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Named","inputs":[
+		{"name":"name","type":"string","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func eventLog(t *testing.T, contractABI *abi.ABI, name string, indexed []interface{}, args ...interface{}) client.EventLog {
+	ev := contractABI.Events[name]
+	var topics []common.Hash
+	if !ev.Anonymous {
+		topics = append(topics, ev.ID)
+	}
+	for _, arg := range indexed {
+		topic, err := abi.MakeTopics([]interface{}{arg})
+		assert.NoError(t, err)
+		topics = append(topics, topic[0][0])
+	}
+	data, err := ev.Inputs.NonIndexed().Pack(args...)
+	assert.NoError(t, err)
+	return client.EventLog{Topics: topics, Data: hexutil.Encode(data)}
+}
+
+func TestContract_UnpackEvent(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	contract := accounts.NewContract(nil, common.Address{}, &contractABI)
+	from, to := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	type Transfer struct {
+		From  common.Address
+		To    common.Address
+		Value *big.Int
+	}
+	transfer, err := accounts.DecodeEvent[Transfer](contract, "Transfer",
+		eventLog(t, &contractABI, "Transfer", []interface{}{from, to}, big.NewInt(7)))
+	assert.NoError(t, err)
+	assert.Equal(t, Transfer{From: from, To: to, Value: big.NewInt(7)}, transfer)
+
+	// no indexed inputs: the log has the event signature only
+	var ping struct {
+		Value *big.Int
+		Note  string
+	}
+	assert.NoError(t, contract.UnpackEvent(&ping, "Ping", eventLog(t, &contractABI, "Ping", nil, big.NewInt(1), "hi")))
+	assert.Equal(t, "hi", ping.Note)
+
+	// anonymous: every topic is an indexed input
+	type Anon struct {
+		Who   common.Address
+		Value *big.Int
+	}
+	anon, err := accounts.DecodeEventLogs[Anon](contract, "Anon",
+		[]client.EventLog{eventLog(t, &contractABI, "Anon", []interface{}{from}, big.NewInt(2))})
+	assert.NoError(t, err)
+	assert.Equal(t, []Anon{{Who: from, Value: big.NewInt(2)}}, anon)
+
+	// dynamic indexed inputs are stored as their hash
+	var named struct {
+		Name  common.Hash
+		Value *big.Int
+	}
+	namedLog := eventLog(t, &contractABI, "Named", []interface{}{"alice"}, big.NewInt(3))
+	assert.NoError(t, contract.UnpackEvent(&named, "Named", namedLog))
+	assert.Equal(t, crypto.Keccak256Hash([]byte("alice")), named.Name)
+
+	// errors instead of silently skipped logs
+	_, err = accounts.DecodeEvent[Transfer](contract, "Transfer", namedLog)
+	assert.ErrorIs(t, err, accounts.ErrEventMismatch)
+	truncated := eventLog(t, &contractABI, "Transfer", []interface{}{from, to}, big.NewInt(7))
+	truncated.Topics = truncated.Topics[:2]
+	_, err = accounts.DecodeEvent[Transfer](contract, "Transfer", truncated)
+	assert.ErrorIs(t, err, accounts.ErrEventMismatch)
+}
+
+func TestContract_DecodeEvents(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	contract := accounts.NewContract(nil, common.Address{}, &contractABI)
+
+	decoded, err := contract.DecodeEvents([]client.EventLog{
+		eventLog(t, &contractABI, "Ping", nil, big.NewInt(1), "hi"),
+		eventLog(t, &contractABI, "Named", []interface{}{"alice"}, big.NewInt(3)),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, decoded, 2, "logs with a single topic are decoded")
+	assert.Equal(t, "hi", decoded[0].Args["note"])
+	assert.Equal(t, crypto.Keccak256Hash([]byte("alice")), decoded[1].Args["name"])
+
+	_, err = contract.DecodeEvents([]client.EventLog{eventLog(t, &contractABI, "Anon", []interface{}{common.Address{}}, big.NewInt(2))})
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultCriteriaLimit is the default maximum number of criteria built by a CriteriaBuilder.
+const DefaultCriteriaLimit = 256
+
+// ErrTooManyCriteria is returned when a criteria set would exceed the limit of the builder.
+var ErrTooManyCriteria = errors.New("too many event criteria")
+
+// CriteriaBuilder builds a criteria set for the events of a contract. The node returns the logs matching any of
+// the criteria of the set, so each event, and each accepted value of its indexed inputs, is expanded into
+// criteria.
+//
+// For example, to match the transfers to any of several addresses, and every approval:
+//
+//	criteria, err := contract.Criteria().
+//	  Event("Transfer", nil, accounts.AnyOf(recipients...)).
+//	  Event("Approval").
+//	  Build()
+type CriteriaBuilder struct {
+	contract *Contract
+	events   []eventMatchers
+	limit    int
+}
+
+type eventMatchers struct {
+	name     string
+	matchers [][]interface{}
+}
+
+// AnyOf returns a list of accepted values for an indexed input, for CriteriaBuilder.Event.
+func AnyOf[T any](values ...T) []interface{} {
+	list := make([]interface{}, len(values))
+	for i, v := range values {
+		list[i] = v
+	}
+	return list
+}
+
+// Criteria returns a builder of criteria sets for the events of the contract.
+func (c *Contract) Criteria() *CriteriaBuilder {
+	return &CriteriaBuilder{contract: c, limit: DefaultCriteriaLimit}
+}
+
+// Event adds an event to the set. Matchers correspond to the event inputs, in order, and hold the accepted values
+// of each indexed input. A nil or empty list accepts any value.
+func (b *CriteriaBuilder) Event(name string, matchers ...[]interface{}) *CriteriaBuilder {
+	b.events = append(b.events, eventMatchers{name: name, matchers: matchers})
+	return b
+}
+
+// Limit sets the maximum number of criteria, to stay within the limit of the node. Defaults to
+// DefaultCriteriaLimit.
+func (b *CriteriaBuilder) Limit(limit int) *CriteriaBuilder {
+	b.limit = limit
+	return b
+}
+
+// Build expands the events into a criteria set. ErrTooManyCriteria is returned if the set exceeds the limit.
+func (b *CriteriaBuilder) Build() ([]client.EventCriteria, error) {
+	if len(b.events) == 0 {
+		return nil, errors.New("no event added to the criteria")
+	}
+
+	var set []client.EventCriteria
+	for _, e := range b.events {
+		topics, err := b.topics(e)
+		if err != nil {
+			return nil, err
+		}
+		// the expansion is the product of the lists, so the size is checked before expanding
+		count := 1
+		for _, values := range topics {
+			count *= max(len(values), 1)
+			if len(set)+count > b.limit {
+				return nil, fmt.Errorf("%w: event %s exceeds the limit of %d", ErrTooManyCriteria, e.name, b.limit)
+			}
+		}
+		set = append(set, b.expand(topics)...)
+	}
+	return set, nil
+}
+
+// topics returns the accepted values of the five topics of an event's logs. A nil list accepts any value.
+func (b *CriteriaBuilder) topics(e eventMatchers) ([5][]common.Hash, error) {
+	var topics [5][]common.Hash
+	ev, ok := b.contract.ABI.Events[e.name]
+	if !ok {
+		return topics, fmt.Errorf("event %s not found", e.name)
+	}
+	if len(e.matchers) > len(ev.Inputs) {
+		return topics, fmt.Errorf("event %s has %d inputs, got %d matchers", e.name, len(ev.Inputs), len(e.matchers))
+	}
+
+	position := 0
+	if !ev.Anonymous {
+		topics[0] = []common.Hash{ev.ID}
+		position = 1
+	}
+	for i, input := range ev.Inputs {
+		if !input.Indexed {
+			if i < len(e.matchers) && len(e.matchers[i]) > 0 {
+				return topics, fmt.Errorf("event %s: can't match non-indexed input %s", e.name, input.Name)
+			}
+			continue
+		}
+		if i < len(e.matchers) && len(e.matchers[i]) > 0 {
+			for _, value := range e.matchers[i] {
+				topic, err := abi.MakeTopics([]interface{}{value})
+				if err != nil {
+					return topics, fmt.Errorf("event %s: input %s: %w", e.name, input.Name, err)
+				}
+				topics[position] = append(topics[position], topic[0][0])
+			}
+		}
+		position++
+	}
+	return topics, nil
+}
+
+// expand returns the criteria of every combination of accepted topics.
+func (b *CriteriaBuilder) expand(topics [5][]common.Hash) []client.EventCriteria {
+	set := []client.EventCriteria{{Address: &b.contract.Address}}
+	for position, values := range topics {
+		if len(values) == 0 {
+			continue
+		}
+		expanded := make([]client.EventCriteria, 0, len(set)*len(values))
+		for _, criteria := range set {
+			for _, value := range values {
+				topic := value
+				switch position {
+				case 0:
+					criteria.Topic0 = &topic
+				case 1:
+					criteria.Topic1 = &topic
+				case 2:
+					criteria.Topic2 = &topic
+				case 3:
+					criteria.Topic3 = &topic
+				case 4:
+					criteria.Topic4 = &topic
+				}
+				expanded = append(expanded, criteria)
+			}
+		}
+		set = expanded
+	}
+	return set
+}
@@ -0,0 +1,76 @@
+package accounts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCriteriaBuilder(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	contract := accounts.NewContract(nil, common.HexToAddress("0x01"), &contractABI)
+
+	from := []common.Address{common.HexToAddress("0x0a"), common.HexToAddress("0x0b")}
+	to := []common.Address{common.HexToAddress("0x0c"), common.HexToAddress("0x0d"), common.HexToAddress("0x0e")}
+	set, err := contract.Criteria().
+		Event("Transfer", accounts.AnyOf(from...), accounts.AnyOf(to...)).
+		Event("Ping").
+		Event("Anon", accounts.AnyOf(from[0])).
+		Build()
+	assert.NoError(t, err)
+	assert.Len(t, set, 2*3+1+1)
+
+	transfer := contractABI.Events["Transfer"].ID
+	for i, criteria := range set[:6] {
+		assert.Equal(t, contract.Address, *criteria.Address)
+		assert.Equal(t, transfer, *criteria.Topic0)
+		assert.Equal(t, common.BytesToHash(from[i/3].Bytes()), *criteria.Topic1)
+		assert.Equal(t, common.BytesToHash(to[i%3].Bytes()), *criteria.Topic2)
+		assert.Nil(t, criteria.Topic3)
+	}
+
+	ping := set[6]
+	assert.Equal(t, contractABI.Events["Ping"].ID, *ping.Topic0)
+	assert.Nil(t, ping.Topic1)
+
+	// the indexed inputs of an anonymous event start at the first topic
+	anon := set[7]
+	assert.Equal(t, common.BytesToHash(from[0].Bytes()), *anon.Topic0)
+	assert.Nil(t, anon.Topic1)
+
+	// a nil list matches any value
+	set, err = contract.Criteria().Event("Transfer", nil, accounts.AnyOf(to...)).Build()
+	assert.NoError(t, err)
+	assert.Len(t, set, 3)
+	for _, criteria := range set {
+		assert.Nil(t, criteria.Topic1)
+		assert.NotNil(t, criteria.Topic2)
+	}
+}
+
+func TestCriteriaBuilder_Errors(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	contract := accounts.NewContract(nil, common.Address{}, &contractABI)
+
+	_, err = contract.Criteria().Build()
+	assert.Error(t, err)
+
+	_, err = contract.Criteria().Event("Approval").Build()
+	assert.ErrorContains(t, err, "event Approval not found")
+
+	_, err = contract.Criteria().Event("Ping", accounts.AnyOf(1)).Build()
+	assert.ErrorContains(t, err, "non-indexed input value")
+
+	addresses := make([]common.Address, 20)
+	_, err = contract.Criteria().Event("Transfer", accounts.AnyOf(addresses...), accounts.AnyOf(addresses...)).Build()
+	assert.ErrorIs(t, err, accounts.ErrTooManyCriteria)
+
+	_, err = contract.Criteria().Limit(2).Event("Ping").Event("Named").Event("Transfer").Build()
+	assert.ErrorIs(t, err, accounts.ErrTooManyCriteria)
+}
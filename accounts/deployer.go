@@ -1,9 +1,11 @@
 package accounts
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/darrenvechain/thorgo/client"
 	"github.com/darrenvechain/thorgo/crypto/tx"
@@ -13,13 +15,13 @@ import (
 )
 
 type Deployer struct {
-	client   *client.Client
+	client   client.Backend
 	bytecode []byte
 	abi      *abi.ABI
 	value    *big.Int
 }
 
-func NewDeployer(client *client.Client, bytecode []byte, abi *abi.ABI) *Deployer {
+func NewDeployer(client client.Backend, bytecode []byte, abi *abi.ABI) *Deployer {
 	return &Deployer{client: client, bytecode: bytecode, abi: abi, value: big.NewInt(0)}
 }
 
@@ -29,16 +31,25 @@ func (d *Deployer) WithValue(value *big.Int) *Deployer {
 }
 
 func (d *Deployer) Deploy(sender TxManager, args ...interface{}) (*Contract, common.Hash, error) {
+	return d.DeployWithContext(context.Background(), sender, args...)
+}
+
+// DeployWithContext is like Deploy but stops waiting for the deployment receipt when the context is done.
+func (d *Deployer) DeployWithContext(
+	ctx context.Context,
+	sender TxManager,
+	args ...interface{},
+) (*Contract, common.Hash, error) {
 	clause, err := d.AsClause(args...)
 	txID := common.Hash{}
 	if err != nil {
 		return nil, txID, fmt.Errorf("failed to pack contract arguments: %w", err)
 	}
-	txID, err = sender.SendClauses([]*tx.Clause{clause})
+	txID, err = sendClauses(ctx, sender, []*tx.Clause{clause})
 	if err != nil {
 		return nil, txID, fmt.Errorf("failed to send contract deployment transaction: %w", err)
 	}
-	receipt, err := transactions.New(d.client, txID).Wait()
+	receipt, err := waitForReceipt(ctx, transactions.New(d.client, txID))
 	if err != nil {
 		return nil, txID, fmt.Errorf("failed to wait for contract deployment: %w", err)
 	}
@@ -61,3 +72,13 @@ func (d *Deployer) AsClause(args ...interface{}) (*tx.Clause, error) {
 	clause := tx.NewClause(nil).WithData(bytecode).WithValue(d.value)
 	return clause, nil
 }
+
+// waitForReceipt keeps the default one minute wait unless the context already has a deadline.
+func waitForReceipt(ctx context.Context, visitor *transactions.Visitor) (*client.TransactionReceipt, error) {
+	if _, ok := ctx.Deadline(); ok {
+		return visitor.WaitWithContext(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	return visitor.WaitWithContext(ctx)
+}
@@ -0,0 +1,183 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+var errNotExecuted = errors.New("the multicall has not been executed")
+
+// Multicall queues read-only calls of many contracts and methods, and sends them as the clauses of a single
+// inspection. Each call gets its own result, and a call which reverts doesn't fail the others:
+//
+//	calls := accounts.NewMulticall(c)
+//	balances := make([]*accounts.CallResult, len(holders))
+//	for i, holder := range holders {
+//	  balances[i] = calls.Add(token, "balanceOf", holder)
+//	}
+//	if err := calls.Execute(); err != nil {
+//	  return err
+//	}
+//	var balance *big.Int
+//	err := balances[0].Decode(&balance)
+//
+// The node stops executing the clauses of an inspection at the first one which fails, so the calls following a
+// failed call are sent again in a new request. The calls share the gas of a request, and a call running out of the
+// gas left by the calls before it is retried in a new request too.
+type Multicall struct {
+	client   client.Backend
+	revision *client.Revision
+	gas      *uint64
+	calls    []*CallResult
+}
+
+// CallResult is the result of a call queued in a Multicall, available once the multicall is executed.
+type CallResult struct {
+	contract *Contract
+	method   string
+	clause   *tx.Clause
+	data     []byte
+	err      error
+}
+
+// NewMulticall creates an empty Multicall.
+func NewMulticall(c client.Backend) *Multicall {
+	return &Multicall{client: c}
+}
+
+// Revision sets the revision the calls are answered at, the best block by default. The revisions of the
+// contracts are ignored.
+func (m *Multicall) Revision(revision client.Revision) *Multicall {
+	m.revision = &revision
+	return m
+}
+
+// Gas sets the gas available to each request, shared by its calls. Defaults to the limit of the node.
+func (m *Multicall) Gas(gas uint64) *Multicall {
+	m.gas = &gas
+	return m
+}
+
+// Add queues a call of a method of a contract, and returns its result. An error packing the arguments is
+// returned by the result.
+func (m *Multicall) Add(contract *Contract, method string, args ...interface{}) *CallResult {
+	result := &CallResult{contract: contract, method: method}
+	result.clause, result.err = contract.AsClause(method, args...)
+	if result.err == nil {
+		result.err = errNotExecuted
+	}
+	m.calls = append(m.calls, result)
+	return result
+}
+
+// Len returns the number of calls queued.
+func (m *Multicall) Len() int {
+	return len(m.calls)
+}
+
+// Execute sends the calls queued and sets their results. The error is only that of a request, the errors of
+// the calls are returned by their results. A multicall can be executed again to refresh its results.
+func (m *Multicall) Execute() error {
+	return m.ExecuteWithContext(context.Background())
+}
+
+// ExecuteWithContext is like Execute but uses the given context for the requests.
+func (m *Multicall) ExecuteWithContext(ctx context.Context) error {
+	pending := make([]*CallResult, 0, len(m.calls))
+	for _, call := range m.calls {
+		if call.clause != nil {
+			pending = append(pending, call)
+		}
+	}
+
+	for len(pending) > 0 {
+		request := client.InspectRequest{Clauses: make([]*tx.Clause, len(pending)), Gas: m.gas}
+		for i, call := range pending {
+			request.Clauses[i] = call.clause
+		}
+		response, err := inspect(ctx, m.client, request, m.revision)
+		if err != nil {
+			return fmt.Errorf("failed to inspect contracts: %w", err)
+		}
+		if len(response) == 0 || len(response) > len(pending) {
+			return fmt.Errorf("unexpected inspection of %d clauses for %d calls", len(response), len(pending))
+		}
+
+		done := len(response)
+		for i, inspection := range response {
+			if i > 0 && inspection.VmError == vm.ErrOutOfGas.Error() {
+				done = i
+				break
+			}
+			pending[i].data, pending[i].err = inspectionOutput(inspection)
+		}
+		pending = pending[done:]
+	}
+	return nil
+}
+
+// Contract returns the contract called.
+func (r *CallResult) Contract() *Contract {
+	return r.contract
+}
+
+// Method returns the name of the method called.
+func (r *CallResult) Method() string {
+	return r.method
+}
+
+// Err returns the error of the call: an error packing its arguments, its revert or VM error, or an error if the
+// multicall hasn't been executed.
+func (r *CallResult) Err() error {
+	return r.err
+}
+
+// Data returns the output data of the call.
+func (r *CallResult) Data() ([]byte, error) {
+	return r.data, r.err
+}
+
+// Decode unpacks the outputs of the call into value, as Contract.Call does.
+func (r *CallResult) Decode(value interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if err := r.contract.ABI.UnpackIntoInterface(value, r.method, r.data); err != nil {
+		return fmt.Errorf("failed to unpack method %s: %w", r.method, err)
+	}
+	return nil
+}
+
+// inspect sends an inspection at a revision, or at the best block if revision is nil.
+func inspect(
+	ctx context.Context,
+	c client.Backend,
+	request client.InspectRequest,
+	revision *client.Revision,
+) ([]client.InspectResponse, error) {
+	if revision == nil {
+		return c.InspectWithContext(ctx, request)
+	}
+	return c.InspectAtWithContext(ctx, request, *revision)
+}
+
+// inspectionOutput returns the output data of an inspected clause, or an error if it reverted.
+func inspectionOutput(inspection client.InspectResponse) ([]byte, error) {
+	if inspection.Reverted {
+		return nil, errors.New("contract call reverted")
+	}
+	if inspection.VmError != "" {
+		return nil, errors.New(inspection.VmError)
+	}
+	decoded, err := hexutil.Decode(inspection.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,136 @@
+package accounts_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/simulated"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingBackend counts the inspections sent to the backend.
+type countingBackend struct {
+	client.Backend
+	inspections int
+}
+
+func (b *countingBackend) InspectWithContext(
+	ctx context.Context,
+	body client.InspectRequest,
+) ([]client.InspectResponse, error) {
+	b.inspections++
+	return b.Backend.InspectWithContext(ctx, body)
+}
+
+func (b *countingBackend) InspectAtWithContext(
+	ctx context.Context,
+	body client.InspectRequest,
+	revision client.Revision,
+) ([]client.InspectResponse, error) {
+	b.inspections++
+	return b.Backend.InspectAtWithContext(ctx, body, revision)
+}
+
+func newCountingBackend(t *testing.T) *countingBackend {
+	backend, err := simulated.NewBackend(nil)
+	assert.NoError(t, err)
+	return &countingBackend{Backend: backend}
+}
+
+func TestMulticall(t *testing.T) {
+	backend := newCountingBackend(t)
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	params := accounts.NewContract(backend, builtins.Params.Address, builtins.Params.ABI)
+	holder := txmanager.FromPK(solo.Keys()[0], nil).Address()
+
+	calls := accounts.NewMulticall(backend)
+	symbol := calls.Add(energy, "symbol")
+	balance := calls.Add(energy, "balanceOf", holder)
+	// transfers from the zero address revert, which doesn't fail the calls after it
+	reverted := calls.Add(energy, "transfer", common.HexToAddress("0x1234"), big.NewInt(1))
+	empty := calls.Add(energy, "balanceOf", common.HexToAddress("0x1234"))
+	baseGasPrice := calls.Add(params, "get", common.BytesToHash([]byte("base-gas-price")))
+	invalid := calls.Add(energy, "balanceOf", "not an address")
+	assert.Equal(t, 6, calls.Len())
+	assert.Error(t, symbol.Err())
+
+	assert.NoError(t, calls.Execute())
+	// the calls after the revert are sent again
+	assert.Equal(t, 2, backend.inspections)
+
+	var s string
+	assert.NoError(t, symbol.Decode(&s))
+	assert.Equal(t, "VTHO", s)
+	var b *big.Int
+	assert.NoError(t, balance.Decode(&b))
+	assert.Positive(t, b.Sign())
+	assert.Same(t, energy, balance.Contract())
+	assert.Equal(t, "balanceOf", balance.Method())
+
+	assert.ErrorContains(t, reverted.Err(), "reverted")
+	assert.Error(t, reverted.Decode(new(bool)))
+
+	assert.NoError(t, empty.Decode(&b))
+	assert.Zero(t, b.Sign())
+	var price *big.Int
+	assert.NoError(t, baseGasPrice.Decode(&price))
+	assert.Positive(t, price.Sign())
+
+	assert.ErrorContains(t, invalid.Err(), "failed to pack method balanceOf")
+	data, err := invalid.Data()
+	assert.Error(t, err)
+	assert.Nil(t, data)
+}
+
+func TestMulticall_Revision(t *testing.T) {
+	ctx := context.Background()
+	backend := newCountingBackend(t)
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	holder := txmanager.FromPK(solo.Keys()[0], nil).Address()
+
+	calls := accounts.NewMulticall(backend).Revision(client.RevisionNumber(0))
+	balance := calls.Add(energy, "balanceOf", holder)
+	assert.NoError(t, calls.ExecuteWithContext(ctx))
+
+	var expected *big.Int
+	assert.NoError(t, energy.At(client.RevisionNumber(0)).CallWithContext(ctx, "balanceOf", &expected, holder))
+	var b *big.Int
+	assert.NoError(t, balance.Decode(&b))
+	assert.Equal(t, expected, b)
+}
+
+func TestMulticall_Gas(t *testing.T) {
+	backend := newCountingBackend(t)
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	holder := txmanager.FromPK(solo.Keys()[0], nil).Address()
+
+	clause, err := energy.AsClause("balanceOf", holder)
+	assert.NoError(t, err)
+	response, err := backend.InspectWithContext(context.Background(), client.InspectRequest{
+		Clauses: []*tx.Clause{clause},
+	})
+	assert.NoError(t, err)
+
+	// each request only has the gas of about two calls, the calls running out of gas are retried
+	calls := accounts.NewMulticall(backend).Gas(response[0].GasUsed * 5 / 2)
+	results := make([]*accounts.CallResult, 5)
+	for i := range results {
+		results[i] = calls.Add(energy, "balanceOf", holder)
+	}
+	backend.inspections = 0
+	assert.NoError(t, calls.Execute())
+	assert.Equal(t, 3, backend.inspections)
+	for _, result := range results {
+		var b *big.Int
+		assert.NoError(t, result.Decode(&b))
+		assert.Positive(t, b.Sign())
+	}
+}
@@ -0,0 +1,180 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownEvent is reported for the logs which don't match any event of an EventRegistry.
+var ErrUnknownEvent = errors.New("no registered event matches the log")
+
+// EventRegistry decodes the logs of many contracts at once. Events are registered for a contract address, or for
+// any address, and logs are matched by address and signature, so one registry can decode the mixed logs of a
+// block, a receipt or a simulation.
+//
+//	registry := accounts.NewEventRegistry().
+//	  Register(builtins.VTHO.Address, builtins.VTHO.ABI).
+//	  RegisterAny(erc721ABI)
+//	decoded := registry.DecodeLogs(logs)
+//
+// Events registered for an address take precedence over those registered for any address. Since events with the
+// same signature can differ by the inputs which are indexed, as the Transfer events of ERC20 and ERC721 tokens,
+// they are also told apart by their number of topics. Anonymous events have no signature and are not registered.
+//
+// An EventRegistry is not safe for concurrent registration, but once populated, it can decode concurrently.
+type EventRegistry struct {
+	byAddress map[common.Address]map[eventKey]*abi.Event
+	wildcard  map[eventKey]*abi.Event
+}
+
+// eventKey identifies the event of a log: its signature and number of topics.
+type eventKey struct {
+	id     common.Hash
+	topics int
+}
+
+// DecodedLogs is the result of EventRegistry decoding.
+type DecodedLogs struct {
+	// Events are the decoded events, in the order of the logs.
+	Events []Event
+	// Unmatched are the logs which couldn't be decoded, in order.
+	Unmatched []UnmatchedLog
+}
+
+// UnmatchedLog is a log which couldn't be decoded.
+type UnmatchedLog struct {
+	// Index is the position of the log in the decoded logs.
+	Index int
+	Log   client.EventLog
+	// Err is ErrUnknownEvent when no event matches the log, or the error of decoding the matched event.
+	Err error
+}
+
+// NewEventRegistry creates an empty registry.
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		byAddress: make(map[common.Address]map[eventKey]*abi.Event),
+		wildcard:  make(map[eventKey]*abi.Event),
+	}
+}
+
+// Register adds the events of an ABI, emitted by the contract at address. It replaces the events with the same
+// signature and topics registered before for the address.
+func (r *EventRegistry) Register(address common.Address, contractABI *abi.ABI) *EventRegistry {
+	events, ok := r.byAddress[address]
+	if !ok {
+		events = make(map[eventKey]*abi.Event)
+		r.byAddress[address] = events
+	}
+	register(events, contractABI)
+	return r
+}
+
+// RegisterContract adds the events of a contract.
+func (r *EventRegistry) RegisterContract(contract *Contract) *EventRegistry {
+	return r.Register(contract.Address, contract.ABI)
+}
+
+// RegisterAny adds the events of an ABI, emitted by any contract, such as the events of a token standard. It
+// replaces the events with the same signature and topics registered before for any contract.
+func (r *EventRegistry) RegisterAny(contractABI *abi.ABI) *EventRegistry {
+	register(r.wildcard, contractABI)
+	return r
+}
+
+func register(events map[eventKey]*abi.Event, contractABI *abi.ABI) {
+	for _, event := range contractABI.Events {
+		if event.Anonymous {
+			continue
+		}
+		event := event
+		events[eventKey{id: event.ID, topics: len(indexedInputs(&event)) + 1}] = &event
+	}
+}
+
+// Event returns the event matching a log emitted by address, if any.
+func (r *EventRegistry) Event(address common.Address, topics []common.Hash) (*abi.Event, bool) {
+	if len(topics) == 0 {
+		return nil, false
+	}
+	key := eventKey{id: topics[0], topics: len(topics)}
+	if event, ok := r.byAddress[address][key]; ok {
+		return event, true
+	}
+	event, ok := r.wildcard[key]
+	return event, ok
+}
+
+// DecodeLogs decodes event logs, such as the logs returned by FilterEvents.
+func (r *EventRegistry) DecodeLogs(logs []client.EventLog) *DecodedLogs {
+	decoded := &DecodedLogs{Events: make([]Event, 0, len(logs))}
+	for i, log := range logs {
+		var address common.Address
+		if log.Address != nil {
+			address = *log.Address
+		}
+		r.decode(decoded, i, address, log)
+	}
+	return decoded
+}
+
+// DecodeReceipt decodes the events of the clauses of a transaction receipt. The logs of the events hold the
+// metadata of the receipt, with the clause index of their output.
+func (r *EventRegistry) DecodeReceipt(receipt *client.TransactionReceipt) *DecodedLogs {
+	decoded := &DecodedLogs{}
+	index := 0
+	for clause, output := range receipt.Outputs {
+		for _, event := range output.Events {
+			meta := client.LogMeta{
+				BlockID:     receipt.Meta.BlockID,
+				BlockNumber: receipt.Meta.BlockNumber,
+				BlockTime:   receipt.Meta.BlockTimestamp,
+				TxID:        receipt.Meta.TxID,
+				TxOrigin:    receipt.Meta.TxOrigin,
+				ClauseIndex: int64(clause),
+			}
+			r.decode(decoded, index, event.Address, outputLog(event, meta))
+			index++
+		}
+	}
+	return decoded
+}
+
+// DecodeInspection decodes the events of the clauses of an inspection. The logs of the events only hold the
+// clause index of their response.
+func (r *EventRegistry) DecodeInspection(responses []client.InspectResponse) *DecodedLogs {
+	decoded := &DecodedLogs{}
+	index := 0
+	for clause, response := range responses {
+		for _, event := range response.Events {
+			r.decode(decoded, index, event.Address, outputLog(event, client.LogMeta{ClauseIndex: int64(clause)}))
+			index++
+		}
+	}
+	return decoded
+}
+
+func (r *EventRegistry) decode(decoded *DecodedLogs, index int, address common.Address, log client.EventLog) {
+	eventABI, ok := r.Event(address, log.Topics)
+	if !ok {
+		decoded.Unmatched = append(decoded.Unmatched, UnmatchedLog{Index: index, Log: log, Err: ErrUnknownEvent})
+		return
+	}
+	event, err := decodeEvent(eventABI, log)
+	if err != nil {
+		err = fmt.Errorf("event %s: %w", eventABI.Name, err)
+		decoded.Unmatched = append(decoded.Unmatched, UnmatchedLog{Index: index, Log: log, Err: err})
+		return
+	}
+	decoded.Events = append(decoded.Events, event)
+}
+
+// outputLog converts an event of a clause output to a log.
+func outputLog(event client.Event, meta client.LogMeta) client.EventLog {
+	address := event.Address
+	return client.EventLog{Address: &address, Topics: event.Topics, Data: event.Data, Meta: meta}
+}
@@ -0,0 +1,99 @@
+package accounts_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const nftABI = `[
+	{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"tokenId","type":"uint256","indexed":true}]}
+]`
+
+func TestEventRegistry_DecodeLogs(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	nft, err := abi.JSON(strings.NewReader(nftABI))
+	assert.NoError(t, err)
+	token, other := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	from, to := common.HexToAddress("0x0a"), common.HexToAddress("0x0b")
+
+	registry := accounts.NewEventRegistry().Register(token, &tokenABI).RegisterAny(&nft)
+
+	at := func(address common.Address, log client.EventLog) client.EventLog {
+		log.Address = &address
+		return log
+	}
+	broken := at(token, eventLog(t, &tokenABI, "Ping", nil, big.NewInt(1), "ping"))
+	broken.Data = "0x01"
+	logs := []client.EventLog{
+		at(token, eventLog(t, &tokenABI, "Transfer", []interface{}{from, to}, big.NewInt(10))),
+		// the same signature with a third topic is the transfer of a token ID
+		at(other, eventLog(t, &nft, "Transfer", []interface{}{from, to, big.NewInt(7)})),
+		at(token, eventLog(t, &nft, "Transfer", []interface{}{from, to, big.NewInt(8)})),
+		// Ping is only registered for the token
+		at(other, eventLog(t, &tokenABI, "Ping", nil, big.NewInt(1), "ping")),
+		broken,
+		at(token, eventLog(t, &tokenABI, "Ping", nil, big.NewInt(2), "pong")),
+	}
+
+	decoded := registry.DecodeLogs(logs)
+	assert.Len(t, decoded.Events, 4)
+	assert.Equal(t, big.NewInt(10), decoded.Events[0].Args["value"])
+	assert.Equal(t, big.NewInt(7), decoded.Events[1].Args["tokenId"])
+	assert.Equal(t, big.NewInt(8), decoded.Events[2].Args["tokenId"])
+	assert.Equal(t, "pong", decoded.Events[3].Args["note"])
+	assert.Equal(t, logs[5], decoded.Events[3].Log)
+
+	assert.Len(t, decoded.Unmatched, 2)
+	assert.Equal(t, 3, decoded.Unmatched[0].Index)
+	assert.ErrorIs(t, decoded.Unmatched[0].Err, accounts.ErrUnknownEvent)
+	assert.Equal(t, 4, decoded.Unmatched[1].Index)
+	assert.ErrorContains(t, decoded.Unmatched[1].Err, "event Ping")
+	assert.Equal(t, broken, decoded.Unmatched[1].Log)
+}
+
+func TestEventRegistry_DecodeOutputs(t *testing.T) {
+	tokenABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	token := common.HexToAddress("0x01")
+	contract := accounts.NewContract(nil, token, &tokenABI)
+	registry := accounts.NewEventRegistry().RegisterContract(contract)
+
+	event := func(address common.Address, value int64) client.Event {
+		log := eventLog(t, &tokenABI, "Transfer", []interface{}{common.Address{}, common.Address{}}, big.NewInt(value))
+		return client.Event{Address: address, Topics: log.Topics, Data: log.Data}
+	}
+	receipt := &client.TransactionReceipt{
+		Meta: client.ReceiptMeta{BlockNumber: 5, TxID: common.HexToHash("0xabcd")},
+		Outputs: []client.Output{
+			{Events: []client.Event{event(token, 1)}},
+			{Events: []client.Event{event(common.HexToAddress("0x02"), 2), event(token, 3)}},
+		},
+	}
+
+	decoded := registry.DecodeReceipt(receipt)
+	assert.Len(t, decoded.Events, 2)
+	assert.Equal(t, big.NewInt(3), decoded.Events[1].Args["value"])
+	assert.Equal(t, int64(1), decoded.Events[1].Log.Meta.ClauseIndex)
+	assert.Equal(t, receipt.Meta.TxID, decoded.Events[1].Log.Meta.TxID)
+	assert.Equal(t, int64(5), decoded.Events[1].Log.Meta.BlockNumber)
+	assert.Len(t, decoded.Unmatched, 1)
+	assert.Equal(t, 1, decoded.Unmatched[0].Index)
+
+	responses := []client.InspectResponse{{Events: receipt.Outputs[1].Events}}
+	decoded = registry.DecodeInspection(responses)
+	assert.Len(t, decoded.Events, 1)
+	assert.Equal(t, token, *decoded.Events[0].Log.Address)
+	assert.Len(t, decoded.Unmatched, 1)
+	assert.Equal(t, 0, decoded.Unmatched[0].Index)
+}
@@ -0,0 +1,326 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/events"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// WatchEvent is an event delivered by Contract.Watch.
+type WatchEvent struct {
+	Event
+	// Retracted is true when the block of an event delivered earlier has been removed from the canonical chain by
+	// a reorganisation, so the event should be undone.
+	Retracted bool
+}
+
+// WatchHandler handles the events of Contract.Watch. An error stops the watch.
+type WatchHandler func(ctx context.Context, event WatchEvent) error
+
+// WatchOption configures Contract.Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	from          *int64
+	confirmations int64
+	finalized     bool
+	pollInterval  time.Duration
+}
+
+// WatchConfirmations delays the delivery of an event until count blocks are built on top of its block.
+func WatchConfirmations(count int64) WatchOption {
+	return func(o *watchOptions) {
+		o.confirmations = count
+	}
+}
+
+// WatchFinalized delays the delivery of an event until its block is finalized. Finalized events are never
+// retracted.
+func WatchFinalized() WatchOption {
+	return func(o *watchOptions) {
+		o.finalized = true
+	}
+}
+
+// WatchFrom starts the watch at the given block number, to deliver past events first.
+func WatchFrom(number int64) WatchOption {
+	return func(o *watchOptions) {
+		o.from = &number
+	}
+}
+
+// WatchPollInterval sets the time between two polls of the node when it has no block subscription. Defaults to 1
+// second.
+func WatchPollInterval(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// Watch calls handler for the events of the contract as they are mined, until the context is done or an error
+// occurs. Matchers are the values of the event inputs, as in EventCriteria, where nil matches any value.
+//
+//	err := contract.Watch(ctx, "Transfer", []interface{}{nil, to}, func(ctx context.Context, ev accounts.WatchEvent) error {
+//	  fmt.Println(ev.Retracted, ev.Args["value"])
+//	  return nil
+//	}, accounts.WatchConfirmations(12))
+//
+// An event is delivered once its block is the best block, or once it has the number of blocks on top set with
+// WatchConfirmations, or once it is finalized with WatchFinalized. The watch starts after the last block which is
+// ready for delivery when it is called, or at WatchFrom.
+//
+// When a reorganisation removes the block of delivered events from the canonical chain, the events are delivered
+// again with Retracted set, newest first, before the events of the new chain.
+//
+// The events are read with FilterEvents by block range. When the backend implements client.Subscriber, the watch
+// wakes up on the blocks of a subscription, otherwise, or once the subscription fails, it polls the node.
+func (c *Contract) Watch(
+	ctx context.Context,
+	eventName string,
+	matchers []interface{},
+	handler WatchHandler,
+	opts ...WatchOption,
+) error {
+	eventABI, ok := c.ABI.Events[eventName]
+	if !ok {
+		return fmt.Errorf("event %s not found", eventName)
+	}
+	values := make([][]interface{}, len(matchers))
+	for i, matcher := range matchers {
+		if !isNilMatcher(matcher) {
+			values[i] = []interface{}{matcher}
+		}
+	}
+	criteria, err := c.Criteria().Event(eventName, values...).Build()
+	if err != nil {
+		return err
+	}
+
+	options := watchOptions{pollInterval: time.Second}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	w := &watcher{client: c.client, event: &eventABI, criteria: criteria, handler: handler, opts: options}
+	if err := w.start(ctx); err != nil {
+		return err
+	}
+	if subscriber, ok := c.client.(client.Subscriber); ok {
+		if sub, err := subscriber.SubscribeBlocks(ctx, nil); err == nil {
+			defer sub.Unsubscribe()
+			w.blocks = sub.C()
+		}
+	}
+	for {
+		if err := w.step(ctx); err != nil {
+			return err
+		}
+		if err := w.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+type watcher struct {
+	client   client.Backend
+	event    *abi.Event
+	criteria []client.EventCriteria
+	handler  WatchHandler
+	opts     watchOptions
+	// blocks wakes the watcher up on new blocks, nil when polling.
+	blocks <-chan client.BlockMessage
+
+	// marks are the handled blocks, oldest first, with the events delivered for them. The first mark is the block
+	// before the start of the watch or the last finalized block handled; the last one is the head of the watch.
+	marks []mark
+}
+
+type mark struct {
+	number int64
+	id     common.Hash
+	events []Event
+}
+
+// start sets the first mark.
+func (w *watcher) start(ctx context.Context) error {
+	var from int64
+	if w.opts.from != nil {
+		from = *w.opts.from
+	} else {
+		target, _, err := w.heads(ctx)
+		if err != nil {
+			return err
+		}
+		from = max(target+1, 0)
+	}
+	if from == 0 {
+		// the genesis block has no parent, and can't be orphaned
+		w.marks = []mark{{number: -1}}
+		return nil
+	}
+	blk, err := w.client.BlockWithContext(ctx, client.RevisionNumber(uint32(from-1)))
+	if err != nil {
+		return err
+	}
+	w.marks = []mark{{number: blk.Number, id: blk.ID}}
+	return nil
+}
+
+// heads returns the number of the last block whose events can be delivered, and the number of the finalized block.
+func (w *watcher) heads(ctx context.Context) (int64, int64, error) {
+	finalized, err := w.client.BlockWithContext(ctx, client.RevisionFinalized)
+	if err != nil {
+		return 0, 0, err
+	}
+	if w.opts.finalized {
+		return finalized.Number, finalized.Number, nil
+	}
+	best, err := w.client.BestBlockWithContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return best.Number - w.opts.confirmations, finalized.Number, nil
+}
+
+// step retracts the events of orphaned blocks, then delivers the events of the blocks which became ready.
+func (w *watcher) step(ctx context.Context) error {
+	target, finalized, err := w.heads(ctx)
+	if err != nil {
+		return err
+	}
+	if err := w.reconcile(ctx); err != nil {
+		return err
+	}
+
+	if head := w.marks[len(w.marks)-1].number; target > head {
+		end, err := w.client.BlockWithContext(ctx, client.RevisionNumber(uint32(target)))
+		if errors.Is(err, client.ErrNotFound) {
+			// replaced by a shorter chain since heads was called
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.deliver(ctx, head+1, end); err != nil {
+			return err
+		}
+	}
+
+	for len(w.marks) > 1 && w.marks[1].number <= finalized {
+		w.marks = w.marks[1:]
+	}
+	w.marks[0].events = nil
+	return nil
+}
+
+// reconcile removes the marks of orphaned blocks, newest first, and retracts their events.
+func (w *watcher) reconcile(ctx context.Context) error {
+	for {
+		last := &w.marks[len(w.marks)-1]
+		if last.number < 0 {
+			return nil
+		}
+		canonical, err := w.client.BlockWithContext(ctx, client.RevisionNumber(uint32(last.number)))
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return err
+		}
+		if err == nil && canonical.ID == last.id {
+			return nil
+		}
+
+		if len(w.marks) == 1 {
+			// the block before the start was orphaned, so the watch restarts from its parent
+			orphan, err := w.client.BlockWithContext(ctx, client.RevisionID(last.id))
+			if err != nil {
+				return err
+			}
+			*last = mark{number: orphan.Number - 1, id: orphan.ParentID}
+			continue
+		}
+		for len(last.events) > 0 {
+			event := last.events[len(last.events)-1]
+			if err := w.handler(ctx, WatchEvent{Event: event, Retracted: true}); err != nil {
+				return err
+			}
+			last.events = last.events[:len(last.events)-1]
+		}
+		w.marks = w.marks[:len(w.marks)-1]
+	}
+}
+
+// deliver delivers the events from the given block number up to the end block, and marks the blocks.
+func (w *watcher) deliver(ctx context.Context, from int64, end *client.Block) error {
+	it := events.New(w.client, w.criteria).BlockRange(from, end.Number).Iterator(ctx, events.IteratorOptions{})
+	defer it.Close()
+	for it.Next() {
+		for _, log := range it.Page() {
+			event, err := decodeEvent(w.event, log)
+			if err != nil {
+				return fmt.Errorf("log of tx %s: %w", log.Meta.TxID, err)
+			}
+			if err := w.handler(ctx, WatchEvent{Event: event}); err != nil {
+				return err
+			}
+			w.mark(log.Meta.BlockNumber, log.Meta.BlockID, event)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	w.mark(end.Number, end.ID)
+	return nil
+}
+
+// mark records the events delivered for a block.
+func (w *watcher) mark(number int64, id common.Hash, events ...Event) {
+	last := &w.marks[len(w.marks)-1]
+	if last.id == id {
+		last.events = append(last.events, events...)
+		return
+	}
+	w.marks = append(w.marks, mark{number: number, id: id, events: events})
+}
+
+// wait returns on the next block of the subscription, or after the poll interval.
+func (w *watcher) wait(ctx context.Context) error {
+	if w.blocks != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-w.blocks:
+			if ok {
+				return nil
+			}
+			w.blocks = nil
+		}
+	}
+	timer := time.NewTimer(w.opts.pollInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isNilMatcher reports whether a matcher matches any value, which includes a nil pointer held by the interface.
+func isNilMatcher(matcher interface{}) bool {
+	if matcher == nil {
+		return true
+	}
+	v := reflect.ValueOf(matcher)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
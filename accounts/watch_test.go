@@ -0,0 +1,130 @@
+package accounts_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/thortest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type watched struct {
+	value     int64
+	retracted bool
+}
+
+// watch runs Watch in the background and returns the channel of the delivered events and of its result.
+func watch(
+	ctx context.Context,
+	contract *accounts.Contract,
+	matchers []interface{},
+	opts ...accounts.WatchOption,
+) (<-chan watched, <-chan error) {
+	delivered := make(chan watched, 16)
+	done := make(chan error, 1)
+	go func() {
+		opts = append(opts, accounts.WatchPollInterval(time.Millisecond))
+		done <- contract.Watch(ctx, "Transfer", matchers, func(_ context.Context, ev accounts.WatchEvent) error {
+			delivered <- watched{ev.Args["value"].(*big.Int).Int64(), ev.Retracted}
+			return nil
+		}, opts...)
+	}()
+	return delivered, done
+}
+
+func next(t *testing.T, delivered <-chan watched) watched {
+	select {
+	case ev := <-delivered:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event delivered")
+		return watched{}
+	}
+}
+
+// addTransfer adds a transfer log to the best block of the node.
+func addTransfer(t *testing.T, node *thortest.Node, contractABI *abi.ABI, contract, to common.Address, value int64) {
+	best := node.Best()
+	log := eventLog(t, contractABI, "Transfer", []interface{}{common.Address{}, to}, big.NewInt(value))
+	log.Address = &contract
+	log.Meta = client.LogMeta{BlockID: best.ID, BlockNumber: best.Number, BlockTime: best.Timestamp}
+	node.AddEventLogs(log)
+}
+
+func TestContract_Watch(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	node := thortest.NewNode(t)
+	node.MineEmpty(2)
+	contract := accounts.NewContract(node.Client(), common.HexToAddress("0x01"), &contractABI)
+	to, other := common.HexToAddress("0x0a"), common.HexToAddress("0x0b")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	delivered, done := watch(ctx, contract, []interface{}{nil, to}, accounts.WatchConfirmations(1), accounts.WatchFrom(3))
+
+	// the block of the transfer needs one block on top
+	node.MineEmpty(1)
+	addTransfer(t, node, &contractABI, contract.Address, to, 1)
+	addTransfer(t, node, &contractABI, contract.Address, other, 2)
+	node.MineEmpty(1)
+	assert.Equal(t, watched{value: 1}, next(t, delivered))
+
+	// the reorg orphans the transfer, and the new chain has another one
+	node.Reorg(2)
+	node.MineEmpty(2)
+	addTransfer(t, node, &contractABI, contract.Address, to, 3)
+	node.MineEmpty(1)
+	assert.Equal(t, watched{value: 1, retracted: true}, next(t, delivered))
+	assert.Equal(t, watched{value: 3}, next(t, delivered))
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Empty(t, delivered)
+}
+
+func TestContract_WatchFinalized(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	node := thortest.NewNode(t)
+	node.MineEmpty(2)
+	addTransfer(t, node, &contractABI, common.HexToAddress("0x01"), common.HexToAddress("0x0a"), 1)
+	node.MineEmpty(1)
+	addTransfer(t, node, &contractABI, common.HexToAddress("0x01"), common.HexToAddress("0x0a"), 2)
+	contract := accounts.NewContract(node.Client(), common.HexToAddress("0x01"), &contractABI)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	delivered, _ := watch(ctx, contract, nil, accounts.WatchFinalized(), accounts.WatchFrom(0))
+
+	node.Finalize(2)
+	assert.Equal(t, watched{value: 1}, next(t, delivered))
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, delivered, "block 3 is not finalized")
+
+	node.Finalize(3)
+	assert.Equal(t, watched{value: 2}, next(t, delivered))
+}
+
+func TestContract_WatchNilPointerMatcher(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(eventsABI))
+	assert.NoError(t, err)
+	node := thortest.NewNode(t)
+	node.MineEmpty(2)
+	addTransfer(t, node, &contractABI, common.HexToAddress("0x01"), common.HexToAddress("0x0a"), 1)
+	node.MineEmpty(1)
+	contract := accounts.NewContract(node.Client(), common.HexToAddress("0x01"), &contractABI)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// a nil pointer matches any value, like an untyped nil
+	var from *common.Address
+	delivered, _ := watch(ctx, contract, []interface{}{from}, accounts.WatchFrom(0))
+	assert.Equal(t, watched{value: 1}, next(t, delivered))
+}
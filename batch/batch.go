@@ -0,0 +1,285 @@
+// Package batch provides a client.Backend decorator which coalesces concurrent inspections into a single request,
+// in the style of a dataloader.
+//
+// A service answering many requests at once calls the same contracts from many goroutines, each call being an
+// inspection of its own. The Backend holds the inspections it receives for a short window, and sends those with
+// the same revision and options as the clauses of a single inspection, up to a number of clauses. Each caller gets
+// the responses of its own clauses, so the call sites don't change:
+//
+//	thor := thorgo.FromClient(batch.New(c, batch.Options{}))
+//
+// Only the inspections are batched, the other requests go to the backend as they are. The clauses of a batch
+// share the state and the gas of a single execution, so only plain read calls are batched: inspections setting
+// any option, such as the caller of a simulated transaction or the gas, and those whose clauses deploy a contract
+// or transfer value are sent on their own. A call which changes the state anyway is seen by the clauses of the
+// other callers following it. The node stops at the first clause which fails, and the inspections following it
+// are sent again in a new batch.
+package batch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// Options configures a Backend.
+type Options struct {
+	// Window is how long an inspection waits for others to batch it with. Defaults to 2 milliseconds.
+	Window time.Duration
+	// MaxClauses is the maximum number of clauses of a batch, which is sent as soon as it is full. Defaults to 100.
+	MaxClauses int
+	// Timeout bounds the requests of a batch, which outlive the contexts of their callers. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// Stats are the counters of a Backend.
+type Stats struct {
+	// Inspections is the number of inspections received.
+	Inspections uint64
+	// Requests is the number of inspections sent to the backend.
+	Requests uint64
+}
+
+// Backend batches the inspections sent to another client.Backend. It is safe for concurrent use.
+type Backend struct {
+	client.Backend
+	opts Options
+
+	mu      sync.Mutex
+	pending map[string]*batch
+
+	inspections atomic.Uint64
+	requests    atomic.Uint64
+}
+
+var _ client.Backend = (*Backend)(nil)
+
+// batch is a set of inspections at the same revision, sent together.
+type batch struct {
+	key string
+	// ctx holds the values of the context of the first inspection, without its cancellation.
+	ctx      context.Context
+	revision *client.Revision
+	calls    []*call
+	clauses  int
+	timer    *time.Timer
+}
+
+// call is an inspection waiting for its responses.
+type call struct {
+	ctx      context.Context
+	clauses  []*tx.Clause
+	response []client.InspectResponse
+	err      error
+	done     chan struct{}
+}
+
+// New creates a batcher in front of the given backend.
+func New(backend client.Backend, opts Options) *Backend {
+	if opts.Window <= 0 {
+		opts.Window = 2 * time.Millisecond
+	}
+	if opts.MaxClauses <= 0 {
+		opts.MaxClauses = 100
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	return &Backend{Backend: backend, opts: opts, pending: make(map[string]*batch)}
+}
+
+// Stats returns the counters of the batcher.
+func (b *Backend) Stats() Stats {
+	return Stats{Inspections: b.inspections.Load(), Requests: b.requests.Load()}
+}
+
+// InspectWithContext batches the inspection at the best block with the concurrent ones.
+func (b *Backend) InspectWithContext(ctx context.Context, body client.InspectRequest) ([]client.InspectResponse, error) {
+	return b.inspect(ctx, body, nil)
+}
+
+// InspectAtWithContext batches the inspection at the revision with the concurrent ones.
+func (b *Backend) InspectAtWithContext(
+	ctx context.Context,
+	body client.InspectRequest,
+	revision client.Revision,
+) ([]client.InspectResponse, error) {
+	return b.inspect(ctx, body, &revision)
+}
+
+func (b *Backend) inspect(
+	ctx context.Context,
+	body client.InspectRequest,
+	revision *client.Revision,
+) ([]client.InspectResponse, error) {
+	b.inspections.Add(1)
+	if !isReadCall(body) || len(body.Clauses) >= b.opts.MaxClauses {
+		return b.send(ctx, body, revision)
+	}
+	key := batchKey(revision)
+
+	c := &call{ctx: ctx, clauses: body.Clauses, done: make(chan struct{})}
+	b.mu.Lock()
+	pending := b.pending[key]
+	if pending != nil && pending.clauses+len(c.clauses) > b.opts.MaxClauses {
+		b.detach(pending)
+		go b.run(pending)
+		pending = nil
+	}
+	if pending == nil {
+		pending = &batch{key: key, ctx: context.WithoutCancel(ctx), revision: revision}
+		b.pending[key] = pending
+		pending.timer = time.AfterFunc(b.opts.Window, func() {
+			b.mu.Lock()
+			detached := b.detach(pending)
+			b.mu.Unlock()
+			if detached {
+				b.run(pending)
+			}
+		})
+	}
+	pending.calls = append(pending.calls, c)
+	pending.clauses += len(c.clauses)
+	if pending.clauses == b.opts.MaxClauses {
+		b.detach(pending)
+		go b.run(pending)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.response, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// detach removes a batch from the pending ones, so no inspection is added to it. It reports whether the batch was
+// pending. It must be called with the lock held.
+func (b *Backend) detach(pending *batch) bool {
+	if b.pending[pending.key] != pending {
+		return false
+	}
+	delete(b.pending, pending.key)
+	pending.timer.Stop()
+	return true
+}
+
+// run sends the inspections of a batch, and hands their responses to the callers.
+func (b *Backend) run(pending *batch) {
+	ctx, cancel := context.WithTimeout(pending.ctx, b.opts.Timeout)
+	defer cancel()
+
+	calls := pending.calls
+	for len(calls) > 0 {
+		if len(calls) == 1 {
+			calls[0].sendAlone(b, pending)
+			return
+		}
+
+		var request client.InspectRequest
+		for _, c := range calls {
+			request.Clauses = append(request.Clauses, c.clauses...)
+		}
+		response, err := b.send(ctx, request, pending.revision)
+		if err != nil {
+			b.fail(pending, calls, err)
+			return
+		}
+
+		var retry []*call
+		offset := 0
+		for _, c := range calls {
+			end := offset + len(c.clauses)
+			switch {
+			case end <= len(response):
+				c.finish(response[offset:end:end], nil)
+			case offset < len(response):
+				// the execution stopped at a clause of the call. It is retried if it ran out of the gas left by the
+				// calls before it, since it would have the gas of the whole request on its own.
+				if offset > 0 && response[len(response)-1].VmError == vm.ErrOutOfGas.Error() {
+					retry = append(retry, c)
+				} else {
+					c.finish(response[offset:len(response):len(response)], nil)
+				}
+			default:
+				retry = append(retry, c)
+			}
+			offset = end
+		}
+		calls = retry
+	}
+}
+
+// fail hands the error of a batch to its calls. A request refused by the node may be refused because of a single
+// call, so each call is then sent on its own.
+func (b *Backend) fail(pending *batch, calls []*call, err error) {
+	var httpErr *client.HttpError
+	var revisionErr *client.RevisionError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest || errors.As(err, &revisionErr) {
+		for _, c := range calls {
+			c.finish(nil, err)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for _, c := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.sendAlone(b, pending)
+		}()
+	}
+	wg.Wait()
+}
+
+func (c *call) sendAlone(b *Backend, pending *batch) {
+	c.finish(b.send(c.ctx, client.InspectRequest{Clauses: c.clauses}, pending.revision))
+}
+
+func (c *call) finish(response []client.InspectResponse, err error) {
+	c.response, c.err = response, err
+	close(c.done)
+}
+
+func (b *Backend) send(
+	ctx context.Context,
+	request client.InspectRequest,
+	revision *client.Revision,
+) ([]client.InspectResponse, error) {
+	b.requests.Add(1)
+	if revision == nil {
+		return b.Backend.InspectWithContext(ctx, request)
+	}
+	return b.Backend.InspectAtWithContext(ctx, request, *revision)
+}
+
+// isReadCall reports whether an inspection is a plain read call, which can share an execution with others: it sets
+// no option, and its clauses call contracts without transferring value.
+func isReadCall(body client.InspectRequest) bool {
+	if body.Gas != nil || body.GasPrice != nil || body.Caller != nil || body.ProvedWork != nil ||
+		body.GasPayer != nil || body.Expiration != nil || body.BlockRef != nil || len(body.Clauses) == 0 {
+		return false
+	}
+	for _, clause := range body.Clauses {
+		if clause == nil || clause.IsCreatingContract() || clause.Value().Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// batchKey identifies the inspections which can be batched together: the read calls at the same revision.
+func batchKey(revision *client.Revision) string {
+	if revision == nil {
+		return ""
+	}
+	return revision.String()
+}
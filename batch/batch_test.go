@@ -0,0 +1,240 @@
+package batch_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/batch"
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/simulated"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingBackend records the number of clauses of the inspections sent to the simulated backend, and fails
+// those for which fail returns an error. If hang is set, the inspections only end with their context.
+type recordingBackend struct {
+	client.Backend
+	fail func(client.InspectRequest) error
+	hang bool
+
+	mu       sync.Mutex
+	requests []int
+}
+
+func (r *recordingBackend) InspectWithContext(
+	ctx context.Context,
+	body client.InspectRequest,
+) ([]client.InspectResponse, error) {
+	return r.InspectAtWithContext(ctx, body, client.RevisionBest)
+}
+
+func (r *recordingBackend) InspectAtWithContext(
+	ctx context.Context,
+	body client.InspectRequest,
+	revision client.Revision,
+) ([]client.InspectResponse, error) {
+	r.mu.Lock()
+	r.requests = append(r.requests, len(body.Clauses))
+	r.mu.Unlock()
+	if r.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if r.fail != nil {
+		if err := r.fail(body); err != nil {
+			return nil, err
+		}
+	}
+	return r.Backend.InspectAtWithContext(ctx, body, revision)
+}
+
+func newBackend(t *testing.T, opts batch.Options) (*recordingBackend, *batch.Backend) {
+	sim, err := simulated.NewBackend(nil)
+	assert.NoError(t, err)
+	recorder := &recordingBackend{Backend: sim}
+	return recorder, batch.New(recorder, opts)
+}
+
+// concurrently runs fn in n goroutines, and waits for them.
+func concurrently(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBackend_Coalesce(t *testing.T) {
+	ctx := context.Background()
+	// the batch is sent as soon as it is full, the window is never reached
+	recorder, backend := newBackend(t, batch.Options{Window: time.Minute, MaxClauses: 20})
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	holder := txmanager.FromPK(solo.Keys()[0], nil).Address()
+
+	balances := make([]*big.Int, 20)
+	concurrently(20, func(i int) {
+		address := holder
+		if i%2 == 1 {
+			address = common.BigToAddress(big.NewInt(int64(i)))
+		}
+		assert.NoError(t, energy.CallWithContext(ctx, "balanceOf", &balances[i], address))
+	})
+
+	assert.Equal(t, []int{20}, recorder.requests)
+	assert.Equal(t, batch.Stats{Inspections: 20, Requests: 1}, backend.Stats())
+	for i, balance := range balances {
+		if i%2 == 1 {
+			assert.Zero(t, balance.Sign())
+		} else {
+			assert.Positive(t, balance.Sign())
+		}
+	}
+}
+
+func TestBackend_Window(t *testing.T) {
+	ctx := context.Background()
+	recorder, backend := newBackend(t, batch.Options{Window: 20 * time.Millisecond})
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+
+	var symbol string
+	assert.NoError(t, energy.CallWithContext(ctx, "symbol", &symbol))
+	assert.Equal(t, "VTHO", symbol)
+
+	// inspections at other revisions aren't batched together, and those which aren't plain read calls aren't
+	// batched at all
+	holder := txmanager.FromPK(solo.Keys()[0], nil).Address()
+	concurrently(6, func(i int) {
+		request := client.InspectRequest{Clauses: clauses(t, energy, "symbol")}
+		var err error
+		switch i {
+		case 0:
+			_, err = backend.InspectWithContext(ctx, request)
+		case 1:
+			_, err = backend.InspectAtWithContext(ctx, request, client.RevisionNumber(0))
+		case 2:
+			gas := uint64(100_000)
+			request.Gas = &gas
+			_, err = backend.InspectWithContext(ctx, request)
+		case 3:
+			// a simulated transaction
+			request.Caller = &holder
+			_, err = backend.InspectWithContext(ctx, request)
+		case 4:
+			request.Clauses = []*tx.Clause{tx.NewClause(&holder).WithValue(big.NewInt(1))}
+			_, err = backend.InspectWithContext(ctx, request)
+		case 5:
+			request.Clauses = []*tx.Clause{tx.NewClause(nil).WithData([]byte{0x60, 0x00})}
+			_, err = backend.InspectWithContext(ctx, request)
+		}
+		assert.NoError(t, err)
+	})
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1}, recorder.requests)
+	assert.Equal(t, batch.Stats{Inspections: 7, Requests: 7}, backend.Stats())
+}
+
+func TestBackend_Revert(t *testing.T) {
+	ctx := context.Background()
+	recorder, backend := newBackend(t, batch.Options{Window: time.Minute, MaxClauses: 6})
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	holder := txmanager.FromPK(solo.Keys()[0], nil).Address()
+
+	// the transfers from the zero address revert, wherever they are in the batch
+	errs := make([]error, 6)
+	balances := make([]*big.Int, 6)
+	concurrently(6, func(i int) {
+		if i%3 == 0 {
+			errs[i] = energy.CallWithContext(ctx, "transfer", new(bool), holder, big.NewInt(1))
+		} else {
+			errs[i] = energy.CallWithContext(ctx, "balanceOf", &balances[i], holder)
+		}
+	})
+
+	for i, err := range errs {
+		if i%3 == 0 {
+			assert.ErrorContains(t, err, "reverted")
+		} else {
+			assert.NoError(t, err)
+			assert.Positive(t, balances[i].Sign())
+		}
+	}
+	total := 0
+	for _, clauses := range recorder.requests {
+		total += clauses
+	}
+	assert.GreaterOrEqual(t, total, 6)
+	assert.Equal(t, 6, recorder.requests[0])
+}
+
+func TestBackend_RefusedBatch(t *testing.T) {
+	ctx := context.Background()
+	recorder, backend := newBackend(t, batch.Options{Window: time.Minute, MaxClauses: 3})
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	recorder.fail = func(request client.InspectRequest) error {
+		if len(request.Clauses) > 1 {
+			return client.NewHttpError(400, "body: too many clauses")
+		}
+		return nil
+	}
+
+	// each inspection is sent on its own when the batch is refused
+	concurrently(3, func(int) {
+		var symbol string
+		assert.NoError(t, energy.CallWithContext(ctx, "symbol", &symbol))
+		assert.Equal(t, "VTHO", symbol)
+	})
+	assert.Equal(t, []int{3, 1, 1, 1}, recorder.requests)
+
+	// other errors are those of every inspection
+	recorder.requests = nil
+	recorder.fail = func(client.InspectRequest) error {
+		return client.NewHttpError(500, "internal error")
+	}
+	concurrently(3, func(int) {
+		assert.Error(t, energy.CallWithContext(ctx, "symbol", new(string)))
+	})
+	assert.Equal(t, []int{3}, recorder.requests)
+}
+
+func TestBackend_Timeout(t *testing.T) {
+	recorder, backend := newBackend(t, batch.Options{Window: time.Minute, MaxClauses: 2, Timeout: 20 * time.Millisecond})
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+	// the node never answers
+	recorder.hang = true
+
+	errs := make([]error, 2)
+	concurrently(2, func(i int) {
+		errs[i] = energy.CallWithContext(context.Background(), "symbol", new(string))
+	})
+	for _, err := range errs {
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+}
+
+func TestBackend_Cancel(t *testing.T) {
+	_, backend := newBackend(t, batch.Options{Window: time.Minute})
+	energy := accounts.NewContract(backend, builtins.VTHO.Address, builtins.VTHO.ABI)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := energy.CallWithContext(ctx, "symbol", new(string))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func clauses(t *testing.T, contract *accounts.Contract, method string) []*tx.Clause {
+	clause, err := contract.AsClause(method)
+	assert.NoError(t, err)
+	return []*tx.Clause{clause}
+}
@@ -1,6 +1,7 @@
 package blocks
 
 import (
+	"context"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -10,21 +11,31 @@ import (
 )
 
 type Blocks struct {
-	client *client.Client
+	client client.Backend
 	best   atomic.Value
 }
 
-func New(c *client.Client) *Blocks {
+func New(c client.Backend) *Blocks {
 	return &Blocks{client: c}
 }
 
 // ByID returns the block by the given ID.
 func (b *Blocks) ByID(id common.Hash) (*client.Block, error) {
-	return b.client.Block(id.Hex())
+	return b.ByIDWithContext(context.Background(), id)
+}
+
+// ByIDWithContext is like ByID but uses the given context for the request.
+func (b *Blocks) ByIDWithContext(ctx context.Context, id common.Hash) (*client.Block, error) {
+	return b.client.BlockWithContext(ctx, client.RevisionID(id))
 }
 
 // Best returns the latest block on chain.
 func (b *Blocks) Best() (block *client.Block, err error) {
+	return b.BestWithContext(context.Background())
+}
+
+// BestWithContext is like Best but uses the given context for the request.
+func (b *Blocks) BestWithContext(ctx context.Context) (block *client.Block, err error) {
 	// Load the best block from the cache.
 	if best, ok := b.best.Load().(*client.Block); ok {
 		// Convert the timestamp to UTC time.
@@ -34,7 +45,7 @@ func (b *Blocks) Best() (block *client.Block, err error) {
 		}
 	}
 
-	block, err = b.client.Block("best")
+	block, err = b.client.BlockWithContext(ctx, client.RevisionBest)
 	if err != nil {
 		return nil, err
 	}
@@ -45,44 +56,80 @@ func (b *Blocks) Best() (block *client.Block, err error) {
 
 // Finalized returns the finalized block.
 func (b *Blocks) Finalized() (*client.Block, error) {
-	return b.client.Block("finalized")
+	return b.FinalizedWithContext(context.Background())
+}
+
+// FinalizedWithContext is like Finalized but uses the given context for the request.
+func (b *Blocks) FinalizedWithContext(ctx context.Context) (*client.Block, error) {
+	return b.client.BlockWithContext(ctx, client.RevisionFinalized)
 }
 
 // Justified returns the justified block.
 func (b *Blocks) Justified() (*client.Block, error) {
-	return b.client.Block("justified")
+	return b.JustifiedWithContext(context.Background())
+}
+
+// JustifiedWithContext is like Justified but uses the given context for the request.
+func (b *Blocks) JustifiedWithContext(ctx context.Context) (*client.Block, error) {
+	return b.client.BlockWithContext(ctx, client.RevisionJustified)
 }
 
 // ByNumber returns the block by the given number.
 func (b *Blocks) ByNumber(number uint64) (*client.Block, error) {
-	return b.client.Block(fmt.Sprintf("%d", number))
+	return b.ByNumberWithContext(context.Background(), number)
+}
+
+// ByNumberWithContext is like ByNumber but uses the given context for the request.
+func (b *Blocks) ByNumberWithContext(ctx context.Context, number uint64) (*client.Block, error) {
+	return b.client.BlockWithContext(ctx, client.RevisionNumber(uint32(number)))
 }
 
 // Expanded returns the expanded block information.
 // This includes the transactions and receipts.
-func (b *Blocks) Expanded(revision string) (*client.ExpandedBlock, error) {
-	return b.client.ExpandedBlock(revision)
+func (b *Blocks) Expanded(revision client.Revision) (*client.ExpandedBlock, error) {
+	return b.ExpandedWithContext(context.Background(), revision)
+}
+
+// ExpandedWithContext is like Expanded but uses the given context for the request.
+func (b *Blocks) ExpandedWithContext(ctx context.Context, revision client.Revision) (*client.ExpandedBlock, error) {
+	return b.client.ExpandedBlockWithContext(ctx, revision)
 }
 
 // Ticker waits for the next block to be produced
 // Returns the next block
 func (b *Blocks) Ticker() (*client.Block, error) {
-	best, err := b.Best()
+	return b.TickerWithContext(context.Background())
+}
+
+// TickerWithContext is like Ticker but stops waiting as soon as the context is done.
+func (b *Blocks) TickerWithContext(ctx context.Context) (*client.Block, error) {
+	best, err := b.BestWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Sleep until the current block + 10 seconds
 	predictedTime := time.Unix(best.Timestamp, 0).Add(10 * time.Second)
-	time.Sleep(time.Until(predictedTime))
+	wait := time.NewTimer(time.Until(predictedTime))
+	defer wait.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-wait.C:
+	}
 
 	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
 	timeout := time.NewTimer(30 * time.Second)
+	defer timeout.Stop()
 
 	for {
 		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		case <-ticker.C:
-			nextBlock, err := b.client.Block(fmt.Sprintf("%d", best.Number+1))
+			nextBlock, err := b.client.BlockWithContext(ctx, client.RevisionNumber(uint32(best.Number+1)))
 			if err == nil {
 				return nextBlock, nil
 			}
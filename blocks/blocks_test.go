@@ -1,7 +1,9 @@
 package blocks
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/darrenvechain/thorgo/client"
 	"github.com/darrenvechain/thorgo/solo"
@@ -42,9 +44,9 @@ func TestGetFinalizedBlock(t *testing.T) {
 }
 
 // TestGetExpandedBlock fetches a block where all the transactions are expanded
-// It accepts a revision, which can be a block ID, block number, "best" or "finalized"
+// It accepts a revision, which can be a block ID, block number, best, justified or finalized
 func TestGetExpandedBlock(t *testing.T) {
-	block, err := blocks.Expanded(solo.GenesisID().Hex())
+	block, err := blocks.Expanded(client.RevisionID(solo.GenesisID()))
 	assert.NoError(t, err)
 	assert.NotNil(t, block)
 }
@@ -55,3 +57,13 @@ func TestWaitForNextBlock(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, block)
 }
+
+// TestTickerWithContext stops waiting for the next block once the context is done
+func TestTickerWithContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	block, err := blocks.TickerWithContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, block)
+}
@@ -0,0 +1,105 @@
+package blocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Checkpoint is the position of a stream: the last block of the canonical chain it has handled.
+type Checkpoint struct {
+	Number int64       `json:"number"`
+	ID     common.Hash `json:"id"`
+}
+
+// CheckpointStore saves the position of a stream, so that a restarted stream resumes where it stopped.
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, or nil if there is none.
+	Load(ctx context.Context) (*Checkpoint, error)
+	// Save replaces the saved checkpoint.
+	Save(ctx context.Context, checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory. It is safe for concurrent use.
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+// NewMemoryCheckpointStore creates an empty in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{}
+}
+
+func (s *MemoryCheckpointStore) Load(context.Context) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	checkpoint := *s.checkpoint
+	return &checkpoint, nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = &checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a JSON file. The file is replaced atomically, so a crash while
+// saving leaves the previous checkpoint in place.
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore creates a checkpoint store backed by the file at path. The file is created on the first
+// save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load(context.Context) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := new(Checkpoint)
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+func (s *FileCheckpointStore) Save(_ context.Context, checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
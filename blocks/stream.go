@@ -0,0 +1,180 @@
+package blocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+)
+
+// ErrReorgTooDeep is returned by Stream when more blocks than StreamOptions.MaxReorgDepth are rolled back in a row.
+var ErrReorgTooDeep = errors.New("blocks: reorg deeper than the maximum depth")
+
+// StreamOptions configures Stream.
+type StreamOptions struct {
+	// From is the number of the first block streamed when the store holds no checkpoint.
+	From int64
+	// Checkpoints saves the position of the stream after each event. Defaults to an in-memory store.
+	Checkpoints CheckpointStore
+	// PollInterval is the time between two requests for the next block once the stream has caught up with the
+	// chain. Defaults to 1 second.
+	PollInterval time.Duration
+	// MaxReorgDepth is the maximum number of blocks rolled back in a row. Defaults to 100.
+	MaxReorgDepth int
+}
+
+// StreamEvent is a block added to, or removed from, the canonical chain.
+type StreamEvent struct {
+	Block *client.Block
+	// Rollback is true when Block has been removed from the canonical chain by a reorganisation.
+	Rollback bool
+}
+
+// StreamHandler handles the events of a stream. An error stops the stream before the checkpoint is saved, so the
+// event is delivered again when the stream is restarted.
+type StreamHandler func(ctx context.Context, event StreamEvent) error
+
+// Stream walks the canonical chain forward and calls handler for every block, in order, until the context is done
+// or an error occurs. The stream starts after the block of the saved checkpoint, or at opts.From.
+//
+// Each block is checked against its predecessor with ParentID. When the chain is reorganised, the orphaned blocks
+// are delivered again, newest first, with Rollback set, down to the common ancestor, and then the blocks of the
+// new chain follow. The checkpoint is saved after each event: after a rollback it points to the parent of the
+// removed block. A stream restarted from the checkpoint therefore resumes exactly where it stopped, even if the
+// checkpoint block was orphaned in the meantime.
+//
+// Stream returns the first error of the node, the handler or the store. Since progress is saved, it can simply be
+// called again.
+func (b *Blocks) Stream(ctx context.Context, opts StreamOptions, handler StreamHandler) error {
+	if opts.Checkpoints == nil {
+		opts.Checkpoints = NewMemoryCheckpointStore()
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.MaxReorgDepth == 0 {
+		opts.MaxReorgDepth = 100
+	}
+
+	s := &stream{blocks: b, opts: opts, handler: handler}
+	if err := s.start(ctx); err != nil {
+		return err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.step(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+type stream struct {
+	blocks  *Blocks
+	opts    StreamOptions
+	handler StreamHandler
+
+	// head is the last block handled, nil until the first one.
+	head *client.Block
+	// depth is the number of blocks rolled back in a row.
+	depth int
+}
+
+// start loads the head from the checkpoint store.
+func (s *stream) start(ctx context.Context) error {
+	checkpoint, err := s.opts.Checkpoints.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("blocks: load checkpoint: %w", err)
+	}
+	if checkpoint == nil {
+		return nil
+	}
+	// the block is fetched by ID, which works for orphaned blocks too
+	s.head, err = s.blocks.client.BlockWithContext(ctx, client.RevisionID(checkpoint.ID))
+	if err != nil {
+		return fmt.Errorf("blocks: checkpoint block %s: %w", checkpoint.ID, err)
+	}
+	return nil
+}
+
+// step handles the next block, rolls back the head or waits for the next block.
+func (s *stream) step(ctx context.Context) error {
+	number := s.opts.From
+	if s.head != nil {
+		number = s.head.Number + 1
+	}
+
+	next, err := s.blocks.client.BlockWithContext(ctx, client.RevisionNumber(uint32(number)))
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		if s.head == nil {
+			return s.wait(ctx)
+		}
+		// caught up: the head is either the best block or orphaned by a shorter chain with a higher score
+		canonical, err := s.blocks.client.BlockWithContext(ctx, client.RevisionNumber(uint32(s.head.Number)))
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return err
+		}
+		if err == nil && canonical.ID == s.head.ID {
+			return s.wait(ctx)
+		}
+		return s.rollback(ctx)
+	case err != nil:
+		return err
+	case s.head != nil && next.ParentID != s.head.ID:
+		return s.rollback(ctx)
+	}
+
+	if err := s.handler(ctx, StreamEvent{Block: next}); err != nil {
+		return err
+	}
+	if err := s.save(ctx, Checkpoint{Number: next.Number, ID: next.ID}); err != nil {
+		return err
+	}
+	s.head = next
+	s.depth = 0
+	return nil
+}
+
+// rollback removes the head and moves to its parent.
+func (s *stream) rollback(ctx context.Context) error {
+	if s.depth >= s.opts.MaxReorgDepth {
+		return fmt.Errorf("%w: %d blocks", ErrReorgTooDeep, s.depth)
+	}
+	removed := s.head
+	parent, err := s.blocks.client.BlockWithContext(ctx, client.RevisionID(removed.ParentID))
+	if err != nil {
+		return err
+	}
+	if err := s.handler(ctx, StreamEvent{Block: removed, Rollback: true}); err != nil {
+		return err
+	}
+	if err := s.save(ctx, Checkpoint{Number: parent.Number, ID: parent.ID}); err != nil {
+		return err
+	}
+	s.head = parent
+	s.depth++
+	return nil
+}
+
+func (s *stream) save(ctx context.Context, checkpoint Checkpoint) error {
+	if err := s.opts.Checkpoints.Save(ctx, checkpoint); err != nil {
+		return fmt.Errorf("blocks: save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// wait sleeps for the poll interval.
+func (s *stream) wait(ctx context.Context) error {
+	timer := time.NewTimer(s.opts.PollInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package blocks_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo/blocks"
+	"github.com/darrenvechain/thorgo/thortest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var errStop = errors.New("stop")
+
+type streamed struct {
+	Number   int64
+	ID       common.Hash
+	Rollback bool
+}
+
+// collect returns a handler recording the events, which stops the stream with errStop once stop returns true.
+func collect(events *[]streamed, stop func(blocks.StreamEvent) bool) blocks.StreamHandler {
+	return func(_ context.Context, event blocks.StreamEvent) error {
+		*events = append(*events, streamed{event.Block.Number, event.Block.ID, event.Rollback})
+		if stop(event) {
+			return errStop
+		}
+		return nil
+	}
+}
+
+func TestStream_Reorg(t *testing.T) {
+	node := thortest.NewNode(t)
+	node.MineEmpty(3)
+	b := blocks.New(node.Client())
+	store := blocks.NewMemoryCheckpointStore()
+
+	var events []streamed
+	reorged := false
+	err := b.Stream(context.Background(), blocks.StreamOptions{Checkpoints: store, PollInterval: time.Millisecond},
+		collect(&events, func(event blocks.StreamEvent) bool {
+			if event.Block.Number == 3 && !reorged {
+				reorged = true
+				node.Reorg(2)
+				node.MineEmpty(3)
+			}
+			return event.Block.Number == 4
+		}))
+	assert.ErrorIs(t, err, errStop)
+
+	numbers := make([]int64, 0, len(events))
+	for _, event := range events {
+		if event.Rollback {
+			numbers = append(numbers, -event.Number)
+		} else {
+			numbers = append(numbers, event.Number)
+		}
+	}
+	assert.Equal(t, []int64{0, 1, 2, 3, -3, -2, 2, 3, 4}, numbers)
+	assert.NotEqual(t, events[2].ID, events[6].ID)
+	assert.Equal(t, events[2].ID, events[5].ID)
+
+	// the handler failed on block 4, so the checkpoint is still block 3 of the new chain
+	checkpoint, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &blocks.Checkpoint{Number: 3, ID: events[7].ID}, checkpoint)
+}
+
+func TestStream_ResumeAfterReorg(t *testing.T) {
+	node := thortest.NewNode(t)
+	node.MineEmpty(3)
+	b := blocks.New(node.Client())
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	opts := blocks.StreamOptions{From: 1, Checkpoints: blocks.NewFileCheckpointStore(path), PollInterval: time.Millisecond}
+
+	var events []streamed
+	err := b.Stream(context.Background(), opts, collect(&events, func(event blocks.StreamEvent) bool {
+		return event.Block.Number == 3
+	}))
+	assert.ErrorIs(t, err, errStop)
+	assert.Len(t, events, 3)
+
+	// while stopped, the checkpoint block 2 and block 3 are replaced by a shorter chain
+	orphan := events[1]
+	node.Reorg(2)
+	node.MineEmpty(1)
+
+	events = nil
+	err = b.Stream(context.Background(), opts, collect(&events, func(event blocks.StreamEvent) bool {
+		return !event.Rollback
+	}))
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, []streamed{
+		{Number: 2, ID: orphan.ID, Rollback: true},
+		{Number: 2, ID: node.Best().ID},
+	}, events)
+
+	// caught up: the stream polls until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = b.Stream(ctx, opts, collect(&events, func(blocks.StreamEvent) bool { return false }))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, events, 3, "the block which failed is delivered again")
+	assert.Equal(t, events[1], events[2])
+}
@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ client.Backend = (*Backend)(nil)
+
+// GenesisBlock returns the genesis block of the chain.
+func (c *Backend) GenesisBlock() *client.Block {
+	return c.backend.GenesisBlock()
+}
+
+// ChainTag returns the chain tag of the genesis block.
+func (c *Backend) ChainTag() byte {
+	return c.backend.ChainTag()
+}
+
+func (c *Backend) AccountWithContext(ctx context.Context, addr common.Address) (*client.Account, error) {
+	return get(c, "account/"+addr.Hex()+"/"+client.RevisionBest.String(), func() (*client.Account, error) {
+		return c.backend.AccountWithContext(ctx, addr)
+	}, never[*client.Account])
+}
+
+func (c *Backend) AccountAtWithContext(
+	ctx context.Context,
+	addr common.Address,
+	revision client.Revision,
+) (*client.Account, error) {
+	return get(c, "account/"+addr.Hex()+"/"+revision.String(), func() (*client.Account, error) {
+		return c.backend.AccountAtWithContext(ctx, addr, revision)
+	}, func(*client.Account) bool {
+		return c.isPinned(ctx, revision)
+	})
+}
+
+func (c *Backend) InspectWithContext(ctx context.Context, body client.InspectRequest) ([]client.InspectResponse, error) {
+	key, err := json.Marshal(body)
+	if err != nil {
+		return c.backend.InspectWithContext(ctx, body)
+	}
+	return get(c, "inspect/"+client.RevisionBest.String()+"/"+string(key), func() ([]client.InspectResponse, error) {
+		return c.backend.InspectWithContext(ctx, body)
+	}, never[[]client.InspectResponse])
+}
+
+func (c *Backend) InspectAtWithContext(
+	ctx context.Context,
+	body client.InspectRequest,
+	revision client.Revision,
+) ([]client.InspectResponse, error) {
+	key, err := json.Marshal(body)
+	if err != nil {
+		return c.backend.InspectAtWithContext(ctx, body, revision)
+	}
+	return get(c, "inspect/"+revision.String()+"/"+string(key), func() ([]client.InspectResponse, error) {
+		return c.backend.InspectAtWithContext(ctx, body, revision)
+	}, func([]client.InspectResponse) bool {
+		return c.isPinned(ctx, revision)
+	})
+}
+
+func (c *Backend) AccountCodeWithContext(ctx context.Context, addr common.Address) (*client.AccountCode, error) {
+	return get(c, "code/"+addr.Hex()+"/"+client.RevisionBest.String(), func() (*client.AccountCode, error) {
+		return c.backend.AccountCodeWithContext(ctx, addr)
+	}, never[*client.AccountCode])
+}
+
+func (c *Backend) AccountCodeAtWithContext(
+	ctx context.Context,
+	addr common.Address,
+	revision client.Revision,
+) (*client.AccountCode, error) {
+	return get(c, "code/"+addr.Hex()+"/"+revision.String(), func() (*client.AccountCode, error) {
+		return c.backend.AccountCodeAtWithContext(ctx, addr, revision)
+	}, func(*client.AccountCode) bool {
+		return c.isPinned(ctx, revision)
+	})
+}
+
+func (c *Backend) AccountStorageWithContext(
+	ctx context.Context,
+	addr common.Address,
+	key common.Hash,
+) (*client.AccountStorage, error) {
+	cacheKey := "storage/" + addr.Hex() + "/" + key.Hex() + "/" + client.RevisionBest.String()
+	return get(c, cacheKey, func() (*client.AccountStorage, error) {
+		return c.backend.AccountStorageWithContext(ctx, addr, key)
+	}, never[*client.AccountStorage])
+}
+
+func (c *Backend) AccountStorageAtWithContext(
+	ctx context.Context,
+	addr common.Address,
+	key common.Hash,
+	revision client.Revision,
+) (*client.AccountStorage, error) {
+	cacheKey := "storage/" + addr.Hex() + "/" + key.Hex() + "/" + revision.String()
+	return get(c, cacheKey, func() (*client.AccountStorage, error) {
+		return c.backend.AccountStorageAtWithContext(ctx, addr, key, revision)
+	}, func(*client.AccountStorage) bool {
+		return c.isPinned(ctx, revision)
+	})
+}
+
+// BlockWithContext returns the block of the revision. Finalized blocks fetched by ID or number are kept until
+// evicted, the other ones for the TTL.
+func (c *Backend) BlockWithContext(ctx context.Context, revision client.Revision) (*client.Block, error) {
+	return get(c, "block/"+revision.String(), func() (*client.Block, error) {
+		blk, err := c.backend.BlockWithContext(ctx, revision)
+		if err == nil {
+			observe(c, "block/", blk, blk)
+		}
+		return blk, err
+	}, func(blk *client.Block) bool {
+		return isBlockPinned(revision) && blk.IsFinalized
+	})
+}
+
+func (c *Backend) BestBlockWithContext(ctx context.Context) (*client.Block, error) {
+	return get(c, "block/"+client.RevisionBest.String(), func() (*client.Block, error) {
+		blk, err := c.backend.BestBlockWithContext(ctx)
+		if err == nil {
+			observe(c, "block/", blk, blk)
+		}
+		return blk, err
+	}, never[*client.Block])
+}
+
+func (c *Backend) ExpandedBlockWithContext(
+	ctx context.Context,
+	revision client.Revision,
+) (*client.ExpandedBlock, error) {
+	return get(c, "expanded/"+revision.String(), func() (*client.ExpandedBlock, error) {
+		blk, err := c.backend.ExpandedBlockWithContext(ctx, revision)
+		if err == nil {
+			observe(c, "expanded/", &blk.Block, blk)
+		}
+		return blk, err
+	}, func(blk *client.ExpandedBlock) bool {
+		return isBlockPinned(revision) && blk.IsFinalized
+	})
+}
+
+// isBlockPinned reports whether the revision always selects the same block once it is finalized.
+func isBlockPinned(revision client.Revision) bool {
+	_, byID := revision.ID()
+	_, byNumber := revision.Number()
+	return byID || byNumber
+}
+
+func (c *Backend) SendTransactionWithContext(
+	ctx context.Context,
+	tx *tx.Transaction,
+) (*client.SendTransactionResponse, error) {
+	return c.backend.SendTransactionWithContext(ctx, tx)
+}
+
+func (c *Backend) SendRawTransactionWithContext(
+	ctx context.Context,
+	raw string,
+) (*client.SendTransactionResponse, error) {
+	return c.backend.SendRawTransactionWithContext(ctx, raw)
+}
+
+func (c *Backend) TransactionWithContext(ctx context.Context, id common.Hash) (*client.Transaction, error) {
+	return get(c, "tx/"+id.Hex()+"/", func() (*client.Transaction, error) {
+		return c.backend.TransactionWithContext(ctx, id)
+	}, func(trx *client.Transaction) bool {
+		return c.isIncluded(ctx, trx.Meta.BlockID, trx.Meta.BlockNumber)
+	})
+}
+
+func (c *Backend) TransactionAtWithContext(
+	ctx context.Context,
+	id common.Hash,
+	head common.Hash,
+) (*client.Transaction, error) {
+	return get(c, "tx/"+id.Hex()+"/"+head.Hex(), func() (*client.Transaction, error) {
+		return c.backend.TransactionAtWithContext(ctx, id, head)
+	}, func(trx *client.Transaction) bool {
+		return c.isIncluded(ctx, trx.Meta.BlockID, trx.Meta.BlockNumber)
+	})
+}
+
+func (c *Backend) RawTransactionWithContext(ctx context.Context, id common.Hash) (*client.RawTransaction, error) {
+	return get(c, "rawtx/"+id.Hex()+"/", func() (*client.RawTransaction, error) {
+		return c.backend.RawTransactionWithContext(ctx, id)
+	}, func(raw *client.RawTransaction) bool {
+		return c.isIncluded(ctx, raw.Meta.BlockID, raw.Meta.BlockNumber)
+	})
+}
+
+func (c *Backend) RawTransactionAtWithContext(
+	ctx context.Context,
+	id common.Hash,
+	head common.Hash,
+) (*client.RawTransaction, error) {
+	return get(c, "rawtx/"+id.Hex()+"/"+head.Hex(), func() (*client.RawTransaction, error) {
+		return c.backend.RawTransactionAtWithContext(ctx, id, head)
+	}, func(raw *client.RawTransaction) bool {
+		return c.isIncluded(ctx, raw.Meta.BlockID, raw.Meta.BlockNumber)
+	})
+}
+
+// PendingTransactionWithContext is never cached: the transaction pool changes all the time.
+func (c *Backend) PendingTransactionWithContext(ctx context.Context, id common.Hash) (*client.Transaction, error) {
+	return c.backend.PendingTransactionWithContext(ctx, id)
+}
+
+func (c *Backend) TransactionReceiptWithContext(
+	ctx context.Context,
+	id common.Hash,
+) (*client.TransactionReceipt, error) {
+	return get(c, "receipt/"+id.Hex()+"/", func() (*client.TransactionReceipt, error) {
+		return c.backend.TransactionReceiptWithContext(ctx, id)
+	}, func(receipt *client.TransactionReceipt) bool {
+		return c.isIncluded(ctx, receipt.Meta.BlockID, receipt.Meta.BlockNumber)
+	})
+}
+
+func (c *Backend) TransactionReceiptAtWithContext(
+	ctx context.Context,
+	id common.Hash,
+	head common.Hash,
+) (*client.TransactionReceipt, error) {
+	return get(c, "receipt/"+id.Hex()+"/"+head.Hex(), func() (*client.TransactionReceipt, error) {
+		return c.backend.TransactionReceiptAtWithContext(ctx, id, head)
+	}, func(receipt *client.TransactionReceipt) bool {
+		return c.isIncluded(ctx, receipt.Meta.BlockID, receipt.Meta.BlockNumber)
+	})
+}
+
+// isIncluded reports whether a transaction is included in a finalized block.
+func (c *Backend) isIncluded(ctx context.Context, blockID common.Hash, number int64) bool {
+	return blockID != (common.Hash{}) && c.isFinal(ctx, number)
+}
+
+func (c *Backend) FilterEventsWithContext(ctx context.Context, filter *client.EventFilter) ([]client.EventLog, error) {
+	key, err := json.Marshal(filter)
+	if err != nil {
+		return c.backend.FilterEventsWithContext(ctx, filter)
+	}
+	return get(c, "events/"+string(key), func() ([]client.EventLog, error) {
+		return c.backend.FilterEventsWithContext(ctx, filter)
+	}, func([]client.EventLog) bool {
+		return filter != nil && c.isFinalRange(ctx, filter.Range)
+	})
+}
+
+func (c *Backend) FilterTransfersWithContext(
+	ctx context.Context,
+	filter *client.TransferFilter,
+) ([]client.TransferLog, error) {
+	key, err := json.Marshal(filter)
+	if err != nil {
+		return c.backend.FilterTransfersWithContext(ctx, filter)
+	}
+	return get(c, "transfers/"+string(key), func() ([]client.TransferLog, error) {
+		return c.backend.FilterTransfersWithContext(ctx, filter)
+	}, func([]client.TransferLog) bool {
+		return filter != nil && c.isFinalRange(ctx, filter.Range)
+	})
+}
+
+// isFinalRange reports whether a log filter range ends at a finalized block. Ranges in time are never final.
+func (c *Backend) isFinalRange(ctx context.Context, rng *client.FilterRange) bool {
+	if rng == nil || rng.To == nil || (rng.Unit != nil && *rng.Unit != "block") {
+		return false
+	}
+	return c.isFinal(ctx, *rng.To)
+}
+
+// PeersWithContext is never cached.
+func (c *Backend) PeersWithContext(ctx context.Context) ([]client.Peer, error) {
+	return c.backend.PeersWithContext(ctx)
+}
+
+func never[T any](T) bool {
+	return false
+}
@@ -0,0 +1,140 @@
+// Package cache provides a client.Backend decorator which caches the responses of the node in memory.
+//
+// Thor data becomes immutable once finalized: a finalized block, the transactions and receipts it contains, the
+// state after it and the logs up to it never change. The cache keeps such responses until they are evicted by
+// newer ones, and keeps the responses which may still change, such as the best block or an account at the best
+// block, for a short TTL only. It is meant for indexers and services which read the same blocks, receipts and
+// historical state again and again:
+//
+//	thor := thorgo.FromClient(cache.New(c, cache.Options{}))
+//
+// The state at a block ID is immutable whether or not the block is finalized, since the ID fixes the block. Errors
+// are never cached, and transactions are always sent to the node. The cached values are shared between callers,
+// so they must not be modified.
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// Options configures a Backend.
+type Options struct {
+	// Size is the maximum number of cached responses. Defaults to 4096.
+	Size int
+	// TTL is how long responses which may still change are kept. Defaults to 1 second. A negative TTL disables
+	// caching them.
+	TTL time.Duration
+}
+
+// Stats are the counters of a Backend.
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// Backend caches the responses of another client.Backend. It is safe for concurrent use.
+type Backend struct {
+	backend client.Backend
+	ttl     time.Duration
+	entries *lru.Cache[string, entry]
+
+	// finalized is the number of the latest finalized block seen.
+	finalized atomic.Int64
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+}
+
+type entry struct {
+	value any
+	// expires is the zero time for immutable responses.
+	expires time.Time
+}
+
+// New creates a cache in front of the given backend.
+func New(backend client.Backend, opts Options) *Backend {
+	if opts.Size <= 0 {
+		opts.Size = 4096
+	}
+	if opts.TTL == 0 {
+		opts.TTL = time.Second
+	}
+	return &Backend{
+		backend: backend,
+		ttl:     opts.TTL,
+		entries: lru.NewCache[string, entry](opts.Size),
+	}
+}
+
+// Stats returns the counters of the cache.
+func (c *Backend) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load(), Entries: c.entries.Len()}
+}
+
+// Purge removes every cached response.
+func (c *Backend) Purge() {
+	c.entries.Purge()
+}
+
+// get returns the cached response for key, or fetches it. final reports whether the fetched response is immutable.
+func get[T any](c *Backend, key string, fetch func() (T, error), final func(T) bool) (T, error) {
+	if e, ok := c.entries.Get(key); ok && (e.expires.IsZero() || time.Now().Before(e.expires)) {
+		c.hits.Add(1)
+		return e.value.(T), nil
+	}
+	c.misses.Add(1)
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	if final(value) {
+		c.entries.Add(key, entry{value: value})
+	} else if c.ttl > 0 {
+		c.entries.Add(key, entry{value: value, expires: time.Now().Add(c.ttl)})
+	}
+	return value, nil
+}
+
+// observe records a fetched block. A finalized block is also cached by ID and number, whatever revision it was
+// fetched with.
+func observe[T any](c *Backend, prefix string, blk *client.Block, value T) {
+	if !blk.IsFinalized {
+		return
+	}
+	for {
+		finalized := c.finalized.Load()
+		if blk.Number <= finalized || c.finalized.CompareAndSwap(finalized, blk.Number) {
+			break
+		}
+	}
+	c.entries.Add(prefix+client.RevisionID(blk.ID).String(), entry{value: value})
+	c.entries.Add(prefix+client.RevisionNumber(uint32(blk.Number)).String(), entry{value: value})
+}
+
+// isFinal reports whether the block of the canonical chain with the given number is finalized. The finalized
+// block is fetched again, through the cache, when number is above the latest one seen.
+func (c *Backend) isFinal(ctx context.Context, number int64) bool {
+	if number <= c.finalized.Load() {
+		return true
+	}
+	finalized, err := c.BlockWithContext(ctx, client.RevisionFinalized)
+	return err == nil && number <= finalized.Number
+}
+
+// isPinned reports whether the state at the revision is immutable: the revision is a block ID, or the number of a
+// finalized block.
+func (c *Backend) isPinned(ctx context.Context, revision client.Revision) bool {
+	if _, ok := revision.ID(); ok {
+		return true
+	}
+	if number, ok := revision.Number(); ok {
+		return c.isFinal(ctx, int64(number))
+	}
+	return false
+}
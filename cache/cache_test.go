@@ -0,0 +1,146 @@
+package cache_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo"
+	"github.com/darrenvechain/thorgo/cache"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/thortest"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCache(t *testing.T, opts cache.Options) (*thortest.Node, *cache.Backend, *client.Metrics) {
+	node := thortest.NewNode(t)
+	metrics := client.NewMetrics()
+	c := node.Client(client.WithMiddleware(metrics.Middleware()))
+	metrics.Reset()
+	return node, cache.New(c, opts), metrics
+}
+
+func TestBackend_Blocks(t *testing.T) {
+	ctx := context.Background()
+	node, c, metrics := newCache(t, cache.Options{TTL: -1})
+	node.MineEmpty(3)
+	node.Finalize(2)
+
+	for range 2 {
+		blk, err := c.BlockWithContext(ctx, client.RevisionNumber(1))
+		assert.NoError(t, err)
+		assert.True(t, blk.IsFinalized)
+		_, err = c.BlockWithContext(ctx, client.RevisionNumber(3))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint64(3), metrics.Snapshot()["Block"].Calls, "only the finalized block is cached")
+
+	// a finalized block fetched by name is cached by ID and number too
+	finalized, err := c.BlockWithContext(ctx, client.RevisionFinalized)
+	assert.NoError(t, err)
+	byID, err := c.BlockWithContext(ctx, client.RevisionID(finalized.ID))
+	assert.NoError(t, err)
+	assert.Same(t, finalized, byID)
+	assert.Equal(t, uint64(4), metrics.Snapshot()["Block"].Calls)
+
+	_, err = c.BlockWithContext(ctx, client.RevisionNumber(100))
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	_, err = c.BlockWithContext(ctx, client.RevisionNumber(100))
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	assert.Equal(t, uint64(6), metrics.Snapshot()["Block"].Calls, "errors are not cached")
+}
+
+func TestBackend_TTL(t *testing.T) {
+	ctx := context.Background()
+	node, c, metrics := newCache(t, cache.Options{TTL: 50 * time.Millisecond})
+	addr := common.HexToAddress("0x1234")
+	node.SetAccount(addr, big.NewInt(1), big.NewInt(0))
+
+	_, err := c.BestBlockWithContext(ctx)
+	assert.NoError(t, err)
+	acc, err := c.AccountWithContext(ctx, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), acc.Balance.ToInt().Int64())
+
+	node.Mine()
+	node.SetAccount(addr, big.NewInt(2), big.NewInt(0))
+	best, err := c.BestBlockWithContext(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, best.Number)
+	acc, err = c.AccountWithContext(ctx, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), acc.Balance.ToInt().Int64())
+
+	time.Sleep(60 * time.Millisecond)
+	best, err = c.BestBlockWithContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), best.Number)
+	acc, err = c.AccountWithContext(ctx, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), acc.Balance.ToInt().Int64())
+	assert.Equal(t, uint64(2), metrics.Snapshot()["Account"].Calls)
+}
+
+func TestBackend_State(t *testing.T) {
+	ctx := context.Background()
+	node, c, metrics := newCache(t, cache.Options{TTL: -1})
+	addr := common.HexToAddress("0x1234")
+	node.SetAccount(addr, big.NewInt(1), big.NewInt(0))
+	node.MineEmpty(2)
+
+	// block 2 is not finalized yet: each read goes to the node
+	_, err := c.AccountAtWithContext(ctx, addr, client.RevisionNumber(2))
+	assert.NoError(t, err)
+	node.Finalize(2)
+	for range 2 {
+		acc, err := c.AccountAtWithContext(ctx, addr, client.RevisionNumber(2))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), acc.Balance.ToInt().Int64())
+		_, err = c.AccountStorageAtWithContext(ctx, addr, common.Hash{}, client.RevisionID(node.Best().ID))
+		assert.NoError(t, err)
+		_, err = c.InspectAtWithContext(ctx, client.InspectRequest{}, client.RevisionNumber(1))
+		assert.NoError(t, err)
+	}
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, uint64(2), snapshot["AccountAt"].Calls)
+	assert.Equal(t, uint64(1), snapshot["AccountStorageAt"].Calls)
+	assert.Equal(t, uint64(1), snapshot["InspectAt"].Calls)
+}
+
+func TestBackend_Transactions(t *testing.T) {
+	ctx := context.Background()
+	node, c, metrics := newCache(t, cache.Options{TTL: -1})
+	thor := thorgo.FromClient(c)
+	sender := txmanager.FromPK(solo.Keys()[0], thor)
+	recipient := common.HexToAddress("0x1234")
+
+	id, err := sender.SendClauses([]*tx.Clause{tx.NewClause(&recipient).WithValue(big.NewInt(1))})
+	assert.NoError(t, err)
+	_, err = c.TransactionReceiptWithContext(ctx, id)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	block := node.Mine()
+	_, err = c.TransactionReceiptWithContext(ctx, id)
+	assert.NoError(t, err)
+	node.Finalize(block.Number)
+	for range 2 {
+		receipt, err := c.TransactionReceiptWithContext(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, block.ID, receipt.Meta.BlockID)
+	}
+	assert.Equal(t, uint64(3), metrics.Snapshot()["TransactionReceipt"].Calls)
+
+	rng := &client.FilterRange{From: new(int64), To: &block.Number}
+	for range 2 {
+		_, err = c.FilterTransfersWithContext(ctx, &client.TransferFilter{Range: rng})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint64(1), metrics.Snapshot()["FilterTransfers"].Calls)
+	assert.Positive(t, c.Stats().Hits)
+}
@@ -21,7 +21,7 @@ func TestClient_Account(t *testing.T) {
 func TestClient_AccountAt(t *testing.T) {
 	acc, err := client.AccountAt(
 		common.HexToAddress("0xd1d37b8913563fC25BC5bB2E669eB3dBC6b87762"),
-		solo.GenesisID(),
+		RevisionID(solo.GenesisID()),
 	)
 
 	assert.NoError(t, err)
@@ -39,7 +39,7 @@ func TestClient_AccountCode(t *testing.T) {
 func TestClient_AccountCodeAt(t *testing.T) {
 	res, err := client.AccountCodeAt(
 		common.HexToAddress("0x0000000000000000000000000000456E65726779"),
-		solo.GenesisID(),
+		RevisionID(solo.GenesisID()),
 	)
 	assert.NoError(t, err)
 	assert.Greater(t, len(res.Code), 2)
@@ -59,7 +59,7 @@ func TestClient_AccountStorageAt(t *testing.T) {
 	res, err := client.AccountStorageAt(
 		common.HexToAddress("0x0000000000000000000000000000456E65726779"),
 		common.HexToHash(strings.Repeat("0", 64)),
-		solo.GenesisID(),
+		RevisionID(solo.GenesisID()),
 	)
 
 	assert.NoError(t, err)
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Backend is the node API used by the higher level packages, such as accounts, blocks and transactions.
+// It is implemented by *Client, and can be implemented by decorators, for example a cache, or by test doubles.
+type Backend interface {
+	// GenesisBlock returns the genesis block of the chain.
+	GenesisBlock() *Block
+	// ChainTag returns the chain tag of the genesis block.
+	ChainTag() byte
+
+	AccountWithContext(ctx context.Context, addr common.Address) (*Account, error)
+	AccountAtWithContext(ctx context.Context, addr common.Address, revision Revision) (*Account, error)
+	InspectWithContext(ctx context.Context, body InspectRequest) ([]InspectResponse, error)
+	InspectAtWithContext(ctx context.Context, body InspectRequest, revision Revision) ([]InspectResponse, error)
+	AccountCodeWithContext(ctx context.Context, addr common.Address) (*AccountCode, error)
+	AccountCodeAtWithContext(ctx context.Context, addr common.Address, revision Revision) (*AccountCode, error)
+	AccountStorageWithContext(ctx context.Context, addr common.Address, key common.Hash) (*AccountStorage, error)
+	AccountStorageAtWithContext(
+		ctx context.Context,
+		addr common.Address,
+		key common.Hash,
+		revision Revision,
+	) (*AccountStorage, error)
+
+	BlockWithContext(ctx context.Context, revision Revision) (*Block, error)
+	BestBlockWithContext(ctx context.Context) (*Block, error)
+	ExpandedBlockWithContext(ctx context.Context, revision Revision) (*ExpandedBlock, error)
+
+	SendTransactionWithContext(ctx context.Context, tx *tx.Transaction) (*SendTransactionResponse, error)
+	SendRawTransactionWithContext(ctx context.Context, raw string) (*SendTransactionResponse, error)
+	TransactionWithContext(ctx context.Context, id common.Hash) (*Transaction, error)
+	TransactionAtWithContext(ctx context.Context, id common.Hash, head common.Hash) (*Transaction, error)
+	RawTransactionWithContext(ctx context.Context, id common.Hash) (*RawTransaction, error)
+	RawTransactionAtWithContext(ctx context.Context, id common.Hash, head common.Hash) (*RawTransaction, error)
+	PendingTransactionWithContext(ctx context.Context, id common.Hash) (*Transaction, error)
+	TransactionReceiptWithContext(ctx context.Context, id common.Hash) (*TransactionReceipt, error)
+	TransactionReceiptAtWithContext(ctx context.Context, id common.Hash, head common.Hash) (*TransactionReceipt, error)
+
+	FilterEventsWithContext(ctx context.Context, filter *EventFilter) ([]EventLog, error)
+	FilterTransfersWithContext(ctx context.Context, filter *TransferFilter) ([]TransferLog, error)
+
+	PeersWithContext(ctx context.Context) ([]Peer, error)
+}
+
+// Subscriber is implemented by backends that support websocket subscriptions, such as *Client.
+// Higher level packages check for it with a type assertion and fall back to polling when it is missing.
+type Subscriber interface {
+	SubscribeBlocks(ctx context.Context, pos *common.Hash) (*Subscription[BlockMessage], error)
+	SubscribeEvents(ctx context.Context, criteria EventCriteria, pos *common.Hash) (*Subscription[EventMessage], error)
+	SubscribeTransfers(
+		ctx context.Context,
+		criteria TransferCriteria,
+		pos *common.Hash,
+	) (*Subscription[TransferMessage], error)
+	SubscribeBeats(ctx context.Context, pos *common.Hash) (*Subscription[BeatMessage], error)
+	SubscribeTxPool(ctx context.Context) (*Subscription[TxPoolMessage], error)
+}
+
+var (
+	_ Backend    = (*Client)(nil)
+	_ Subscriber = (*Client)(nil)
+)
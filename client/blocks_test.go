@@ -4,11 +4,12 @@ import (
 	"testing"
 
 	"github.com/darrenvechain/thorgo/solo"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestClient_Block(t *testing.T) {
-	block, err := client.Block("1")
+	block, err := client.Block(RevisionNumber(1))
 	assert.NoError(t, err)
 	assert.NotNil(t, block)
 }
@@ -25,7 +26,7 @@ func TestClient_GenesisBlock(t *testing.T) {
 }
 
 func TestClient_ExpandedBlock(t *testing.T) {
-	block, err := client.ExpandedBlock("0")
+	block, err := client.ExpandedBlock(RevisionNumber(0))
 	assert.NoError(t, err)
 	assert.NotNil(t, block)
 }
@@ -34,7 +35,9 @@ func TestClient_ExpandedBlockWithTxs(t *testing.T) {
 	c, err := FromURL("https://mainnet.vechain.org")
 	assert.NoError(t, err)
 
-	blk, err := c.ExpandedBlock("0x0125fb07988ff3c36b261b5f7227688c1c0473c4873825ac299bc256ea991b0f")
+	blk, err := c.ExpandedBlock(
+		RevisionID(common.HexToHash("0x0125fb07988ff3c36b261b5f7227688c1c0473c4873825ac299bc256ea991b0f")),
+	)
 	assert.NoError(t, err)
 
 	assert.NotNil(t, blk)
@@ -48,7 +51,7 @@ func TestClient_ChainTag(t *testing.T) {
 }
 
 func TestClient_BlockRef(t *testing.T) {
-	genesis, err := client.Block("0")
+	genesis, err := client.Block(RevisionNumber(0))
 	assert.NoError(t, err)
 	assert.NotNil(t, genesis)
 	assert.Equal(t, genesis.BlockRef().Number(), uint32(0))
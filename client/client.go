@@ -2,7 +2,9 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -15,25 +17,36 @@ type Client struct {
 	client       *http.Client
 	url          string
 	genesisBlock *Block
+	retry        RetryPolicy
+	limiter      *RateLimiter
+	middleware   []Middleware
 }
 
-func New(url string, client *http.Client) (*Client, error) {
-	return newClient(url, client)
+func New(url string, client *http.Client, opts ...Option) (*Client, error) {
+	return newClient(context.Background(), url, client, opts...)
 }
 
-func FromURL(url string) (*Client, error) {
-	return New(url, &http.Client{})
+// NewWithContext is like New but uses the given context to fetch the genesis block.
+func NewWithContext(ctx context.Context, url string, client *http.Client, opts ...Option) (*Client, error) {
+	return newClient(ctx, url, client, opts...)
 }
 
-func newClient(url string, client *http.Client) (*Client, error) {
+func FromURL(url string, opts ...Option) (*Client, error) {
+	return New(url, &http.Client{}, opts...)
+}
+
+func newClient(ctx context.Context, url string, client *http.Client, opts ...Option) (*Client, error) {
 	url = strings.TrimSuffix(url, "/")
 
 	c := &Client{
 		client: client,
 		url:    url,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
 
-	block, err := c.Block("0")
+	block, err := c.BlockWithContext(ctx, RevisionNumber(0))
 	if err != nil {
 		return nil, err
 	}
@@ -44,14 +57,24 @@ func newClient(url string, client *http.Client) (*Client, error) {
 
 // Account fetches the account information for the given address.
 func (c *Client) Account(addr common.Address) (*Account, error) {
+	return c.AccountWithContext(context.Background(), addr)
+}
+
+// AccountWithContext is like Account but uses the given context for the request.
+func (c *Client) AccountWithContext(ctx context.Context, addr common.Address) (*Account, error) {
 	url := "/accounts/" + addr.Hex()
-	return httpGet(c, url, &Account{})
+	return httpGet(ctx, c, "Account", url, &Account{})
 }
 
 // AccountAt fetches the account information for an address at the given revision.
-func (c *Client) AccountAt(addr common.Address, revision common.Hash) (*Account, error) {
-	url := "/accounts/" + addr.Hex() + "?revision=" + revision.Hex()
-	return httpGet(c, url, &Account{})
+func (c *Client) AccountAt(addr common.Address, revision Revision) (*Account, error) {
+	return c.AccountAtWithContext(context.Background(), addr, revision)
+}
+
+// AccountAtWithContext is like AccountAt but uses the given context for the request.
+func (c *Client) AccountAtWithContext(ctx context.Context, addr common.Address, revision Revision) (*Account, error) {
+	url := "/accounts/" + addr.Hex() + "?revision=" + revision.String()
+	return httpGet(ctx, c, "AccountAt", url, &Account{})
 }
 
 // Inspect will send an array of clauses to the node to simulate the execution of the clauses.
@@ -59,9 +82,14 @@ func (c *Client) AccountAt(addr common.Address, revision common.Hash) (*Account,
 // - Read contract(s) state
 // - Simulate the execution of a transaction
 func (c *Client) Inspect(body InspectRequest) ([]InspectResponse, error) {
+	return c.InspectWithContext(context.Background(), body)
+}
+
+// InspectWithContext is like Inspect but uses the given context for the request.
+func (c *Client) InspectWithContext(ctx context.Context, body InspectRequest) ([]InspectResponse, error) {
 	url := "/accounts/*"
 	response := make([]InspectResponse, 0)
-	_, err := httpPost(c, url, body, &response)
+	_, err := httpPost(ctx, c, "Inspect", url, body, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -69,10 +97,15 @@ func (c *Client) Inspect(body InspectRequest) ([]InspectResponse, error) {
 }
 
 // InspectAt will send an array of clauses to the node to simulate the execution of the clauses at the given revision.
-func (c *Client) InspectAt(body InspectRequest, revision common.Hash) ([]InspectResponse, error) {
-	url := "/accounts/*?revision=" + revision.Hex()
+func (c *Client) InspectAt(body InspectRequest, revision Revision) ([]InspectResponse, error) {
+	return c.InspectAtWithContext(context.Background(), body, revision)
+}
+
+// InspectAtWithContext is like InspectAt but uses the given context for the request.
+func (c *Client) InspectAtWithContext(ctx context.Context, body InspectRequest, revision Revision) ([]InspectResponse, error) {
+	url := "/accounts/*?revision=" + revision.String()
 	response := make([]InspectResponse, 0)
-	_, err := httpPost(c, url, body, &response)
+	_, err := httpPost(ctx, c, "InspectAt", url, body, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -81,41 +114,76 @@ func (c *Client) InspectAt(body InspectRequest, revision common.Hash) ([]Inspect
 
 // AccountCode fetches the code for the account at the given address.
 func (c *Client) AccountCode(addr common.Address) (*AccountCode, error) {
+	return c.AccountCodeWithContext(context.Background(), addr)
+}
+
+// AccountCodeWithContext is like AccountCode but uses the given context for the request.
+func (c *Client) AccountCodeWithContext(ctx context.Context, addr common.Address) (*AccountCode, error) {
 	url := "/accounts/" + addr.Hex() + "/code"
-	return httpGet(c, url, &AccountCode{})
+	return httpGet(ctx, c, "AccountCode", url, &AccountCode{})
 }
 
 // AccountCodeAt fetches the code for the account at the given address and revision.
-func (c *Client) AccountCodeAt(addr common.Address, revision common.Hash) (*AccountCode, error) {
-	url := "/accounts/" + addr.Hex() + "/code?revision=" + revision.Hex()
-	return httpGet(c, url, &AccountCode{})
+func (c *Client) AccountCodeAt(addr common.Address, revision Revision) (*AccountCode, error) {
+	return c.AccountCodeAtWithContext(context.Background(), addr, revision)
+}
+
+// AccountCodeAtWithContext is like AccountCodeAt but uses the given context for the request.
+func (c *Client) AccountCodeAtWithContext(ctx context.Context, addr common.Address, revision Revision) (*AccountCode, error) {
+	url := "/accounts/" + addr.Hex() + "/code?revision=" + revision.String()
+	return httpGet(ctx, c, "AccountCodeAt", url, &AccountCode{})
 }
 
 // AccountStorage fetches the storage value for the account at the given address and key.
 func (c *Client) AccountStorage(addr common.Address, key common.Hash) (*AccountStorage, error) {
+	return c.AccountStorageWithContext(context.Background(), addr, key)
+}
+
+// AccountStorageWithContext is like AccountStorage but uses the given context for the request.
+func (c *Client) AccountStorageWithContext(ctx context.Context, addr common.Address, key common.Hash) (*AccountStorage, error) {
 	url := "/accounts/" + addr.Hex() + "/storage/" + key.Hex()
-	return httpGet(c, url, &AccountStorage{})
+	return httpGet(ctx, c, "AccountStorage", url, &AccountStorage{})
 }
 
 // AccountStorageAt fetches the storage value for the account at the given address and key at the given revision.
 func (c *Client) AccountStorageAt(
 	addr common.Address,
 	key common.Hash,
-	revision common.Hash,
+	revision Revision,
+) (*AccountStorage, error) {
+	return c.AccountStorageAtWithContext(context.Background(), addr, key, revision)
+}
+
+// AccountStorageAtWithContext is like AccountStorageAt but uses the given context for the request.
+func (c *Client) AccountStorageAtWithContext(
+	ctx context.Context,
+	addr common.Address,
+	key common.Hash,
+	revision Revision,
 ) (*AccountStorage, error) {
-	url := "/accounts/" + addr.Hex() + "/storage/" + key.Hex() + "?revision=" + revision.Hex()
-	return httpGet(c, url, &AccountStorage{})
+	url := "/accounts/" + addr.Hex() + "/storage/" + key.Hex() + "?revision=" + revision.String()
+	return httpGet(ctx, c, "AccountStorageAt", url, &AccountStorage{})
 }
 
 // Block fetches the block for the given revision.
-func (c *Client) Block(revision string) (*Block, error) {
-	url := "/blocks/" + revision
-	return httpGet(c, url, &Block{})
+func (c *Client) Block(revision Revision) (*Block, error) {
+	return c.BlockWithContext(context.Background(), revision)
+}
+
+// BlockWithContext is like Block but uses the given context for the request.
+func (c *Client) BlockWithContext(ctx context.Context, revision Revision) (*Block, error) {
+	url := "/blocks/" + revision.String()
+	return httpGet(ctx, c, "Block", url, &Block{})
 }
 
 // BestBlock returns the best block.
 func (c *Client) BestBlock() (*Block, error) {
-	return c.Block("best")
+	return c.BestBlockWithContext(context.Background())
+}
+
+// BestBlockWithContext is like BestBlock but uses the given context for the request.
+func (c *Client) BestBlockWithContext(ctx context.Context) (*Block, error) {
+	return httpGet(ctx, c, "BestBlock", "/blocks/best", &Block{})
 }
 
 // GenesisBlock returns the genesis block.
@@ -124,9 +192,14 @@ func (c *Client) GenesisBlock() *Block {
 }
 
 // ExpandedBlock fetches the block at the given revision with all the transactions expanded.
-func (c *Client) ExpandedBlock(revision string) (*ExpandedBlock, error) {
-	url := "/blocks/" + revision + "?expanded=true"
-	return httpGet(c, url, &ExpandedBlock{})
+func (c *Client) ExpandedBlock(revision Revision) (*ExpandedBlock, error) {
+	return c.ExpandedBlockWithContext(context.Background(), revision)
+}
+
+// ExpandedBlockWithContext is like ExpandedBlock but uses the given context for the request.
+func (c *Client) ExpandedBlockWithContext(ctx context.Context, revision Revision) (*ExpandedBlock, error) {
+	url := "/blocks/" + revision.String() + "?expanded=true"
+	return httpGet(ctx, c, "ExpandedBlock", url, &ExpandedBlock{})
 }
 
 // ChainTag returns the chain tag of the genesis block.
@@ -136,69 +209,122 @@ func (c *Client) ChainTag() byte {
 
 // SendTransaction sends a transaction to the node.
 func (c *Client) SendTransaction(tx *tx.Transaction) (*SendTransactionResponse, error) {
-	body := make(map[string]string)
+	return c.SendTransactionWithContext(context.Background(), tx)
+}
+
+// SendTransactionWithContext is like SendTransaction but uses the given context for the request.
+func (c *Client) SendTransactionWithContext(ctx context.Context, tx *tx.Transaction) (*SendTransactionResponse, error) {
 	encoded, err := tx.Encoded()
 	if err != nil {
 		return nil, err
 	}
-	body["raw"] = "0x" + encoded
-	return httpPost(c, "/transactions", body, &SendTransactionResponse{})
+	id := tx.ID()
+	return sendTransaction(ctx, c, "SendTransaction", "0x"+encoded, &id)
 }
 
 // SendRawTransaction sends a raw transaction to the node.
 func (c *Client) SendRawTransaction(raw string) (*SendTransactionResponse, error) {
-	body := make(map[string]string)
-	body["raw"] = raw
-	return httpPost(c, "/transactions", body, &SendTransactionResponse{})
+	return c.SendRawTransactionWithContext(context.Background(), raw)
+}
+
+// SendRawTransactionWithContext is like SendRawTransaction but uses the given context for the request.
+func (c *Client) SendRawTransactionWithContext(ctx context.Context, raw string) (*SendTransactionResponse, error) {
+	// the ID is needed to recognise a retried transaction, it is not retried if the raw tx can't be decoded
+	var id *common.Hash
+	if decoded, err := tx.Decode(common.FromHex(raw)); err == nil {
+		txID := decoded.ID()
+		id = &txID
+	}
+	return sendTransaction(ctx, c, "SendRawTransaction", raw, id)
 }
 
 // Transaction fetches a transaction by its ID.
 func (c *Client) Transaction(id common.Hash) (*Transaction, error) {
+	return c.TransactionWithContext(context.Background(), id)
+}
+
+// TransactionWithContext is like Transaction but uses the given context for the request.
+func (c *Client) TransactionWithContext(ctx context.Context, id common.Hash) (*Transaction, error) {
 	url := "/transactions/" + id.Hex()
-	return httpGet(c, url, &Transaction{})
+	return httpGet(ctx, c, "Transaction", url, &Transaction{})
 }
 
 // TransactionAt fetches a transaction by its ID for the given head block ID.
 func (c *Client) TransactionAt(id common.Hash, head common.Hash) (*Transaction, error) {
+	return c.TransactionAtWithContext(context.Background(), id, head)
+}
+
+// TransactionAtWithContext is like TransactionAt but uses the given context for the request.
+func (c *Client) TransactionAtWithContext(ctx context.Context, id common.Hash, head common.Hash) (*Transaction, error) {
 	url := "/transactions/" + id.Hex() + "?head=" + head.Hex()
-	return httpGet(c, url, &Transaction{})
+	return httpGet(ctx, c, "TransactionAt", url, &Transaction{})
 }
 
 // RawTransaction fetches a transaction by its ID and returns the raw transaction.
 func (c *Client) RawTransaction(id common.Hash) (*RawTransaction, error) {
+	return c.RawTransactionWithContext(context.Background(), id)
+}
+
+// RawTransactionWithContext is like RawTransaction but uses the given context for the request.
+func (c *Client) RawTransactionWithContext(ctx context.Context, id common.Hash) (*RawTransaction, error) {
 	url := "/transactions/" + id.Hex() + "?raw=true"
-	return httpGet(c, url, &RawTransaction{})
+	return httpGet(ctx, c, "RawTransaction", url, &RawTransaction{})
 }
 
 // RawTransactionAt fetches a transaction by its ID for the given head block ID and returns the raw transaction.
 func (c *Client) RawTransactionAt(id common.Hash, head common.Hash) (*RawTransaction, error) {
+	return c.RawTransactionAtWithContext(context.Background(), id, head)
+}
+
+// RawTransactionAtWithContext is like RawTransactionAt but uses the given context for the request.
+func (c *Client) RawTransactionAtWithContext(ctx context.Context, id common.Hash, head common.Hash) (*RawTransaction, error) {
 	url := "/transactions/" + id.Hex() + "?head=" + head.Hex() + "&raw=true"
-	return httpGet(c, url, &RawTransaction{})
+	return httpGet(ctx, c, "RawTransactionAt", url, &RawTransaction{})
 }
 
 // PendingTransaction includes the pending block when fetching a transaction.
 func (c *Client) PendingTransaction(id common.Hash) (*Transaction, error) {
+	return c.PendingTransactionWithContext(context.Background(), id)
+}
+
+// PendingTransactionWithContext is like PendingTransaction but uses the given context for the request.
+func (c *Client) PendingTransactionWithContext(ctx context.Context, id common.Hash) (*Transaction, error) {
 	url := "/transactions/" + id.Hex() + "?pending=true"
-	return httpGet(c, url, &Transaction{})
+	return httpGet(ctx, c, "PendingTransaction", url, &Transaction{})
 }
 
 // TransactionReceipt fetches a transaction receipt by its ID.
 func (c *Client) TransactionReceipt(id common.Hash) (*TransactionReceipt, error) {
+	return c.TransactionReceiptWithContext(context.Background(), id)
+}
+
+// TransactionReceiptWithContext is like TransactionReceipt but uses the given context for the request.
+func (c *Client) TransactionReceiptWithContext(ctx context.Context, id common.Hash) (*TransactionReceipt, error) {
 	url := "/transactions/" + id.Hex() + "/receipt"
-	return httpGet(c, url, &TransactionReceipt{})
+	return httpGet(ctx, c, "TransactionReceipt", url, &TransactionReceipt{})
 }
 
 // TransactionReceiptAt fetches a transaction receipt by its ID for the given head block ID.
 func (c *Client) TransactionReceiptAt(id common.Hash, head common.Hash) (*TransactionReceipt, error) {
+	return c.TransactionReceiptAtWithContext(context.Background(), id, head)
+}
+
+// TransactionReceiptAtWithContext is like TransactionReceiptAt but uses the given context for the request.
+func (c *Client) TransactionReceiptAtWithContext(ctx context.Context, id common.Hash, head common.Hash) (*TransactionReceipt, error) {
 	url := "/transactions/" + id.Hex() + "/receipt?revision=" + head.Hex()
-	return httpGet(c, url, &TransactionReceipt{})
+	return httpGet(ctx, c, "TransactionReceiptAt", url, &TransactionReceipt{})
 }
 
 // FilterEvents fetches the event logs that match the given filter.
 func (c *Client) FilterEvents(filter *EventFilter) ([]EventLog, error) {
+	return c.FilterEventsWithContext(context.Background(), filter)
+}
+
+// FilterEventsWithContext is like FilterEvents but uses the given context for the request.
+func (c *Client) FilterEventsWithContext(ctx context.Context, filter *EventFilter) ([]EventLog, error) {
 	path := "/logs/event"
 	events := make([]EventLog, 0)
-	_, err := httpPost(c, path, filter, &events)
+	_, err := httpPost(ctx, c, "FilterEvents", path, filter, &events)
 	if err != nil {
 		return nil, err
 	}
@@ -207,9 +333,14 @@ func (c *Client) FilterEvents(filter *EventFilter) ([]EventLog, error) {
 
 // FilterTransfers fetches the transfer logs that match the given filter.
 func (c *Client) FilterTransfers(filter *TransferFilter) ([]TransferLog, error) {
+	return c.FilterTransfersWithContext(context.Background(), filter)
+}
+
+// FilterTransfersWithContext is like FilterTransfers but uses the given context for the request.
+func (c *Client) FilterTransfersWithContext(ctx context.Context, filter *TransferFilter) ([]TransferLog, error) {
 	path := "/logs/transfer"
 	transfers := make([]TransferLog, 0)
-	_, err := httpPost(c, path, filter, &transfers)
+	_, err := httpPost(ctx, c, "FilterTransfers", path, filter, &transfers)
 	if err != nil {
 		return nil, err
 	}
@@ -218,37 +349,86 @@ func (c *Client) FilterTransfers(filter *TransferFilter) ([]TransferLog, error)
 
 // Peers fetches the list of peers connected to the node.
 func (c *Client) Peers() ([]Peer, error) {
+	return c.PeersWithContext(context.Background())
+}
+
+// PeersWithContext is like Peers but uses the given context for the request.
+func (c *Client) PeersWithContext(ctx context.Context) ([]Peer, error) {
 	path := "/node/network/peers"
 	peers := make([]Peer, 0)
-	_, err := httpGet(c, path, &peers)
+	_, err := httpGet(ctx, c, "Peers", path, &peers)
 	if err != nil {
 		return nil, err
 	}
 	return peers, nil
 }
 
-func httpGet[T any](c *Client, endpoint string, v *T) (*T, error) {
-	req, err := http.NewRequest(http.MethodGet, c.url+endpoint, nil)
+func httpGet[T any](ctx context.Context, c *Client, op string, endpoint string, v *T) (*T, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
-	return httpDo(c, req, v)
+	return execute(ctx, c, op, req, true, func(req *http.Request, _ int) (*T, error) {
+		return httpDo(c, req, v)
+	})
 }
 
-func httpPost[T any](c *Client, path string, body interface{}, v *T) (*T, error) {
+// httpPost sends a request which doesn't change the state of the chain, so it can safely be retried.
+func httpPost[T any](ctx context.Context, c *Client, op string, path string, body interface{}, v *T) (*T, error) {
+	request, err := newPostRequest(ctx, c, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return execute(ctx, c, op, request, true, func(req *http.Request, _ int) (*T, error) {
+		return httpDo(c, req, v)
+	})
+}
+
+// sendTransaction posts a raw transaction. If id is not nil the transaction is retried, and a retry
+// rejected as "known tx" means that a previous attempt reached the node.
+func sendTransaction(
+	ctx context.Context,
+	c *Client,
+	op string,
+	raw string,
+	id *common.Hash,
+) (*SendTransactionResponse, error) {
+	request, err := newPostRequest(ctx, c, "/transactions", map[string]string{"raw": raw})
+	if err != nil {
+		return nil, err
+	}
+	return execute(ctx, c, op, request, id != nil, func(req *http.Request, attempt int) (*SendTransactionResponse, error) {
+		res, err := httpDo(c, req, &SendTransactionResponse{})
+		if attempt > 0 && errors.Is(err, ErrKnownTx) {
+			return &SendTransactionResponse{ID: *id}, nil
+		}
+		return res, err
+	})
+}
+
+func newPostRequest(ctx context.Context, c *Client, path string, body interface{}) (*http.Request, error) {
 	reqBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-	request, err := http.NewRequest(http.MethodPost, c.url+path, strings.NewReader(string(reqBody)))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
-	return httpDo(c, request, v)
+	return request, nil
 }
 
+// httpDo sends a copy of the request, so that it can be sent again, and decodes the response into v.
 func httpDo[T any](c *Client, req *http.Request, v *T) (*T, error) {
+	req = req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
 	response, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -1,7 +1,11 @@
 package client
 
 import (
+	"context"
+	"testing"
+
 	"github.com/darrenvechain/thorgo/solo"
+	"github.com/stretchr/testify/assert"
 )
 
 var client *Client
@@ -13,3 +17,11 @@ func init() {
 		panic(err)
 	}
 }
+
+func TestClient_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.BestBlockWithContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Categories of transactions refused by the node's transaction pool.
+// A rejected transaction matches exactly one category and, when the reason is known, one of the reason errors below.
+var (
+	// ErrBadTx means the transaction is malformed or invalid for this chain and will never be accepted.
+	ErrBadTx = errors.New("bad tx")
+	// ErrTxRejected means the transaction is well-formed but was not accepted in the current state.
+	ErrTxRejected = errors.New("tx rejected")
+)
+
+// Reasons for a transaction to be refused by the node.
+var (
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrChainTagMismatch   = errors.New("chain tag mismatch")
+	ErrInsufficientEnergy = errors.New("insufficient energy")
+	ErrKnownTx            = errors.New("known tx")
+	ErrTxExpired          = errors.New("tx expired")
+	ErrTxPoolFull         = errors.New("tx pool is full")
+)
+
+// ErrInvalidRevision is returned when the node can't parse or resolve the requested revision.
+var ErrInvalidRevision = errors.New("invalid revision")
+
+// ErrRevisionNotFound is returned when the requested revision is well-formed but the block is unknown to the node.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// TxRejectedError describes why the node refused a transaction.
+// Use errors.Is with ErrBadTx or ErrTxRejected to check the category, and with the reason errors, such as ErrKnownTx,
+// to check the cause.
+type TxRejectedError struct {
+	// Reason is the reason reported by the node, for example "insufficient energy".
+	Reason string
+
+	category error
+	cause    error
+}
+
+func (e *TxRejectedError) Error() string {
+	return e.category.Error() + ": " + e.Reason
+}
+
+func (e *TxRejectedError) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.category}
+	}
+	return []error{e.category, e.cause}
+}
+
+// RevisionError is returned when the node refuses the revision of a request.
+type RevisionError struct {
+	// Reason is the message reported by the node.
+	Reason string
+
+	cause error
+}
+
+func (e *RevisionError) Error() string {
+	return e.Reason
+}
+
+func (e *RevisionError) Unwrap() error {
+	return e.cause
+}
+
+var txReasons = []struct {
+	match string
+	err   error
+}{
+	{"signature", ErrInvalidSignature},
+	{"chain tag", ErrChainTagMismatch},
+	{"insufficient energy", ErrInsufficientEnergy},
+	{"known tx", ErrKnownTx},
+	{"expired", ErrTxExpired},
+	{"pool is full", ErrTxPoolFull},
+}
+
+// parseError maps the body of a failed response to a typed error. It returns nil if the body is not recognised.
+func parseError(code int, body string) error {
+	message := strings.TrimSpace(body)
+
+	var category error
+	switch {
+	case strings.HasPrefix(message, "bad tx:"):
+		category = ErrBadTx
+	case strings.HasPrefix(message, "tx rejected:"):
+		category = ErrTxRejected
+	case code == http.StatusBadRequest && strings.HasPrefix(message, "revision"):
+		cause := ErrInvalidRevision
+		if strings.Contains(message, "not found") {
+			cause = ErrRevisionNotFound
+		}
+		return &RevisionError{Reason: message, cause: cause}
+	default:
+		return nil
+	}
+
+	reason := strings.TrimSpace(message[strings.Index(message, ":")+1:])
+	rejected := &TxRejectedError{Reason: reason, category: category}
+	for _, r := range txReasons {
+		if strings.Contains(reason, r.match) {
+			rejected.cause = r.err
+			break
+		}
+	}
+	return rejected
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		body     string
+		code     int
+		category error
+		reason   error
+	}{
+		{"bad tx: chain tag mismatch\n", http.StatusForbidden, ErrBadTx, ErrChainTagMismatch},
+		{"bad tx: invalid signature length", http.StatusForbidden, ErrBadTx, ErrInvalidSignature},
+		{"tx rejected: insufficient energy\n", http.StatusForbidden, ErrTxRejected, ErrInsufficientEnergy},
+		{"tx rejected: known tx", http.StatusForbidden, ErrTxRejected, ErrKnownTx},
+		{"tx rejected: expired", http.StatusForbidden, ErrTxRejected, ErrTxExpired},
+		{"tx rejected: pool is full", http.StatusForbidden, ErrTxRejected, ErrTxPoolFull},
+		{"revision: leveldb: not found\n", http.StatusBadRequest, ErrRevisionNotFound, ErrRevisionNotFound},
+		{"revision: invalid prefix", http.StatusBadRequest, ErrInvalidRevision, ErrInvalidRevision},
+	}
+
+	for _, tt := range tests {
+		err := error(&HttpError{Code: tt.code, Message: tt.body, Err: parseError(tt.code, tt.body)})
+		assert.ErrorIs(t, err, tt.category, tt.body)
+		assert.ErrorIs(t, err, tt.reason, tt.body)
+	}
+}
+
+func TestParseError_TxRejectedReason(t *testing.T) {
+	err := error(&HttpError{Code: http.StatusForbidden, Err: parseError(http.StatusForbidden, "tx rejected: gas too large")})
+
+	var rejected *TxRejectedError
+	assert.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "gas too large", rejected.Reason)
+	assert.ErrorIs(t, err, ErrTxRejected)
+	assert.NotErrorIs(t, err, ErrKnownTx)
+}
+
+func TestParseError_Unknown(t *testing.T) {
+	assert.Nil(t, parseError(http.StatusInternalServerError, "something went wrong"))
+	assert.Nil(t, parseError(http.StatusForbidden, "revision: not a tx error"))
+}
@@ -0,0 +1,419 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FailoverOptions configures a client that spreads requests over several nodes.
+type FailoverOptions struct {
+	// ReadURLs are the nodes used for queries. At least one is required.
+	ReadURLs []string
+	// WriteURLs are the nodes that transactions are broadcast to. Defaults to ReadURLs.
+	WriteURLs []string
+	// HTTPClient is used to reach the nodes. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// HealthCheckInterval is the minimum time between two health checks of a node. Defaults to 10 seconds.
+	HealthCheckInterval time.Duration
+	// MaxBlockAge is the maximum age of a node's best block for the node to be considered healthy.
+	// Defaults to 30 seconds, three missed blocks.
+	MaxBlockAge time.Duration
+}
+
+// NewFailover creates a client backed by several nodes. Reads are spread over the healthy read nodes and fail over
+// to the next node when a node is unreachable or returns a server error. Transactions are broadcast to every write node.
+//
+// A node is healthy when its best block is recent and its genesis block matches the genesis block of the first read
+// node. Health is checked lazily, at most once per HealthCheckInterval, so the client needs no shutdown.
+//
+// Retries configured with WithRetry are sent to the next healthy node.
+func NewFailover(ctx context.Context, opts FailoverOptions, clientOpts ...Option) (*Client, error) {
+	if len(opts.ReadURLs) == 0 {
+		return nil, errors.New("at least one read url is required")
+	}
+	if len(opts.WriteURLs) == 0 {
+		opts.WriteURLs = opts.ReadURLs
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.HealthCheckInterval == 0 {
+		opts.HealthCheckInterval = 10 * time.Second
+	}
+	if opts.MaxBlockAge == 0 {
+		opts.MaxBlockAge = 30 * time.Second
+	}
+
+	transport := &failoverTransport{
+		client:      opts.HTTPClient,
+		interval:    opts.HealthCheckInterval,
+		maxBlockAge: opts.MaxBlockAge,
+		base:        strings.TrimSuffix(opts.ReadURLs[0], "/"),
+	}
+	nodes := make(map[string]*node)
+	for _, u := range opts.ReadURLs {
+		n := transport.node(nodes, u)
+		transport.readers = append(transport.readers, n)
+	}
+	for _, u := range opts.WriteURLs {
+		n := transport.node(nodes, u)
+		transport.writers = append(transport.writers, n)
+	}
+
+	// the reference genesis is set before any health check runs, so that no node is checked against a zero genesis
+	var genesis Block
+	if err := transport.fetch(ctx, transport.readers[0], "/blocks/0", &genesis); err != nil {
+		return nil, fmt.Errorf("failed to fetch the genesis block of %s: %w", transport.readers[0].url, err)
+	}
+	transport.genesis.Store(&genesis.ID)
+	for _, n := range nodes {
+		transport.check(ctx, n)
+	}
+
+	httpClient := &http.Client{Transport: transport, Timeout: opts.HTTPClient.Timeout}
+	c, err := newClient(ctx, transport.base, httpClient, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if c.genesisBlock.ID != genesis.ID {
+		return nil, fmt.Errorf("genesis mismatch: expected %s, got %s", genesis.ID.Hex(), c.genesisBlock.ID.Hex())
+	}
+	return c, nil
+}
+
+// FromURLs creates a failover client which reads from and writes to all the given nodes.
+// Use NewFailover to set the options of the client.
+func FromURLs(urls ...string) (*Client, error) {
+	return NewFailover(context.Background(), FailoverOptions{ReadURLs: urls})
+}
+
+// NodeStatus reports the health of a node of a failover client.
+type NodeStatus struct {
+	URL       string
+	Healthy   bool
+	BestBlock *Block
+	CheckedAt time.Time
+	Err       error
+}
+
+// Nodes returns the status of the nodes behind a failover client, or nil if the client uses a single node.
+func (c *Client) Nodes() []NodeStatus {
+	t, ok := c.client.Transport.(*failoverTransport)
+	if !ok {
+		return nil
+	}
+	seen := make(map[*node]bool)
+	statuses := make([]NodeStatus, 0)
+	for _, n := range append(append([]*node{}, t.readers...), t.writers...) {
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		statuses = append(statuses, n.status())
+	}
+	return statuses
+}
+
+type node struct {
+	url      string
+	checking atomic.Bool
+
+	mu        sync.Mutex
+	healthy   bool
+	wrongNet  bool
+	verified  bool
+	best      *Block
+	checkedAt time.Time
+	err       error
+}
+
+func (n *node) status() NodeStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return NodeStatus{URL: n.url, Healthy: n.healthy, BestBlock: n.best, CheckedAt: n.checkedAt, Err: n.err}
+}
+
+func (n *node) isHealthy() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.healthy
+}
+
+// isWrongNet reports whether the genesis of the node differs from the one of the client.
+func (n *node) isWrongNet() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.wrongNet
+}
+
+func (n *node) markFailed(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.healthy = false
+	n.err = err
+}
+
+// failoverTransport routes the requests of a Client to a set of nodes.
+// Requests are built against the URL of the first read node and rewritten to the selected node.
+type failoverTransport struct {
+	client      *http.Client
+	interval    time.Duration
+	maxBlockAge time.Duration
+	base        string
+	// genesis is the ID of the genesis block of the first read node, nil until it is fetched.
+	genesis atomic.Pointer[common.Hash]
+	readers []*node
+	writers []*node
+	next    atomic.Uint64
+}
+
+func (t *failoverTransport) node(nodes map[string]*node, u string) *node {
+	u = strings.TrimSuffix(u, "/")
+	if n, ok := nodes[u]; ok {
+		return n
+	}
+	// nodes are optimistically healthy until the first check
+	n := &node{url: u, healthy: true}
+	nodes[u] = n
+	return n
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/transactions") {
+		return t.broadcast(req)
+	}
+
+	candidates := t.candidates()
+	var lastErr error
+	for i, n := range candidates {
+		res, err := t.send(req, n)
+		if req.Context().Err() != nil {
+			return res, err
+		}
+		if err != nil {
+			n.markFailed(err)
+			lastErr = err
+			continue
+		}
+		if res.StatusCode < http.StatusInternalServerError && res.StatusCode != http.StatusTooManyRequests {
+			return res, nil
+		}
+		n.markFailed(errors.New(res.Status))
+		// the response of the last node is returned so that the caller can inspect the error
+		if i == len(candidates)-1 {
+			return res, nil
+		}
+		res.Body.Close()
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no healthy nodes available")
+	}
+	return nil, lastErr
+}
+
+// candidates returns the read nodes to try, healthy nodes first, rotating the starting node to spread the load.
+func (t *failoverTransport) candidates() []*node {
+	start := int(t.next.Add(1) % uint64(len(t.readers)))
+	healthy := make([]*node, 0, len(t.readers))
+	unhealthy := make([]*node, 0)
+	for i := range t.readers {
+		n := t.readers[(start+i)%len(t.readers)]
+		t.maybeCheck(n)
+		switch {
+		case n.isWrongNet():
+		case n.isHealthy():
+			healthy = append(healthy, n)
+		default:
+			unhealthy = append(unhealthy, n)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// broadcast sends the transaction to every write node and returns the first successful response.
+// When every node refuses it, the response of the first node that answered is returned.
+func (t *failoverTransport) broadcast(req *http.Request) (*http.Response, error) {
+	// a transaction is never sent to a node of another network
+	writers := make([]*node, 0, len(t.writers))
+	for _, n := range t.writers {
+		t.maybeCheck(n)
+		if !n.isWrongNet() {
+			writers = append(writers, n)
+		}
+	}
+	if len(writers) == 0 {
+		return nil, errors.New("no write nodes available on the network of the client")
+	}
+
+	results := make(chan sendResult, len(writers))
+	for _, n := range writers {
+		go func(n *node) {
+			res, err := t.send(req, n)
+			if err != nil {
+				n.markFailed(err)
+			}
+			results <- sendResult{res, err}
+		}(n)
+	}
+
+	var (
+		rejected *http.Response
+		lastErr  error
+	)
+	for i := range writers {
+		r := <-results
+		switch {
+		case r.err != nil:
+			lastErr = r.err
+		case r.res.StatusCode < 300:
+			if rejected != nil {
+				rejected.Body.Close()
+			}
+			// the remaining nodes are drained in the background
+			go discardResponses(results, len(writers)-i-1)
+			return r.res, nil
+		case rejected == nil:
+			rejected = r.res
+		default:
+			r.res.Body.Close()
+		}
+	}
+
+	if rejected != nil {
+		return rejected, nil
+	}
+	return nil, lastErr
+}
+
+func discardResponses(results <-chan sendResult, n int) {
+	for range n {
+		if r := <-results; r.res != nil {
+			r.res.Body.Close()
+		}
+	}
+}
+
+type sendResult struct {
+	res *http.Response
+	err error
+}
+
+// send issues the request against the given node.
+func (t *failoverTransport) send(req *http.Request, n *node) (*http.Response, error) {
+	target := n.url + strings.TrimPrefix(req.URL.String(), t.base)
+	var body io.ReadCloser
+	if req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+	out, err := http.NewRequestWithContext(req.Context(), req.Method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	out.Header = req.Header.Clone()
+	return t.client.Do(out)
+}
+
+// maybeCheck refreshes the health of a node in the background if its last check is stale.
+func (t *failoverTransport) maybeCheck(n *node) {
+	if t.genesis.Load() == nil {
+		return
+	}
+	n.mu.Lock()
+	stale := time.Since(n.checkedAt) > t.interval
+	n.mu.Unlock()
+	if !stale || !n.checking.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), t.interval)
+		defer cancel()
+		t.check(ctx, n)
+	}()
+}
+
+// check fetches the best block, and the genesis block if not yet verified, from the node.
+func (t *failoverTransport) check(ctx context.Context, n *node) {
+	n.checking.Store(true)
+	defer n.checking.Store(false)
+
+	expected := t.genesis.Load()
+	if expected == nil {
+		return
+	}
+	n.mu.Lock()
+	verified := n.verified
+	n.mu.Unlock()
+
+	var (
+		genesis Block
+		best    Block
+		err     error
+	)
+	if !verified {
+		err = t.fetch(ctx, n, "/blocks/0", &genesis)
+	}
+	if err == nil {
+		err = t.fetch(ctx, n, "/blocks/best", &best)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.checkedAt = time.Now()
+	n.err = err
+	if err != nil {
+		n.healthy = false
+		return
+	}
+	if !verified {
+		n.verified = true
+		if genesis.ID != *expected {
+			n.wrongNet = true
+			n.err = fmt.Errorf("genesis mismatch: expected %s, got %s", expected.Hex(), genesis.ID.Hex())
+		}
+	}
+	n.best = &best
+	age := time.Since(time.Unix(best.Timestamp, 0))
+	n.healthy = !n.wrongNet && age <= t.maxBlockAge
+	if !n.healthy && n.err == nil {
+		n.err = fmt.Errorf("best block is %s old", age.Round(time.Second))
+	}
+}
+
+func (t *failoverTransport) fetch(ctx context.Context, n *node, path string, v *Block) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.url+path, nil)
+	if err != nil {
+		return err
+	}
+	res, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return newHttpError(res)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// readURL returns the base URL of a healthy read node, used by connections that bypass the transport.
+func (t *failoverTransport) readURL() string {
+	candidates := t.candidates()
+	if len(candidates) == 0 {
+		return t.base
+	}
+	return candidates[0].url
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNode struct {
+	*httptest.Server
+	genesis common.Hash
+	failing atomic.Bool
+	reads   atomic.Int32
+	sent    atomic.Int32
+}
+
+func newTestNode(genesis common.Hash) *testNode {
+	n := &testNode{genesis: genesis}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n.failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/blocks/0":
+			_ = json.NewEncoder(w).Encode(Block{ID: n.genesis})
+		case "/blocks/best":
+			_ = json.NewEncoder(w).Encode(Block{Number: 10, Timestamp: time.Now().Unix()})
+		case "/transactions":
+			n.sent.Add(1)
+			_ = json.NewEncoder(w).Encode(SendTransactionResponse{ID: common.HexToHash("0x01")})
+		default:
+			n.reads.Add(1)
+			_ = json.NewEncoder(w).Encode(Account{})
+		}
+	}))
+	return n
+}
+
+func TestFailover(t *testing.T) {
+	genesis := common.HexToHash("0xabcd")
+	healthy := newTestNode(genesis)
+	defer healthy.Close()
+	failing := newTestNode(genesis)
+	defer failing.Close()
+	otherNet := newTestNode(common.HexToHash("0x1234"))
+	defer otherNet.Close()
+
+	c, err := NewFailover(context.Background(), FailoverOptions{
+		ReadURLs: []string{healthy.URL, failing.URL, otherNet.URL},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, genesis, c.GenesisBlock().ID)
+
+	for _, status := range c.Nodes() {
+		assert.Equal(t, status.URL != otherNet.URL, status.Healthy, status.URL)
+	}
+
+	failing.failing.Store(true)
+	for range 4 {
+		_, err := c.Account(common.Address{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(0), otherNet.reads.Load())
+	assert.Equal(t, int32(4), healthy.reads.Load())
+}
+
+func TestFailover_GenesisOfFirstNode(t *testing.T) {
+	first := newTestNode(common.HexToHash("0x1234"))
+	defer first.Close()
+	second := newTestNode(common.HexToHash("0xabcd"))
+	defer second.Close()
+
+	c, err := NewFailover(context.Background(), FailoverOptions{ReadURLs: []string{first.URL, second.URL}})
+	assert.NoError(t, err)
+	assert.Equal(t, first.genesis, c.GenesisBlock().ID)
+	for _, status := range c.Nodes() {
+		assert.Equal(t, status.URL == first.URL, status.Healthy, status.URL)
+	}
+
+	first.failing.Store(true)
+	_, err = NewFailover(context.Background(), FailoverOptions{ReadURLs: []string{first.URL, second.URL}})
+	assert.ErrorContains(t, err, "failed to fetch the genesis block")
+}
+
+func TestFailover_Broadcast(t *testing.T) {
+	genesis := common.HexToHash("0xabcd")
+	read := newTestNode(genesis)
+	defer read.Close()
+	writeA := newTestNode(genesis)
+	defer writeA.Close()
+	writeB := newTestNode(genesis)
+	defer writeB.Close()
+
+	c, err := NewFailover(context.Background(), FailoverOptions{
+		ReadURLs:  []string{read.URL},
+		WriteURLs: []string{writeA.URL, writeB.URL},
+	})
+	assert.NoError(t, err)
+
+	res, err := c.SendRawTransaction("0x00")
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x01"), res.ID)
+
+	assert.Eventually(t, func() bool {
+		return writeA.sent.Load() == 1 && writeB.sent.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(0), read.sent.Load())
+}
+
+func TestFailover_BroadcastSkipsOtherNetworks(t *testing.T) {
+	genesis := common.HexToHash("0xabcd")
+	read := newTestNode(genesis)
+	defer read.Close()
+	write := newTestNode(genesis)
+	defer write.Close()
+	otherNet := newTestNode(common.HexToHash("0x1234"))
+	defer otherNet.Close()
+
+	c, err := NewFailover(context.Background(), FailoverOptions{
+		ReadURLs:  []string{read.URL},
+		WriteURLs: []string{write.URL, otherNet.URL},
+	})
+	assert.NoError(t, err)
+	_, err = c.SendRawTransaction("0x00")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return write.sent.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(0), otherNet.sent.Load())
+
+	// no writer is left on the network of the client
+	c, err = NewFailover(context.Background(), FailoverOptions{
+		ReadURLs:  []string{read.URL},
+		WriteURLs: []string{otherNet.URL},
+	})
+	assert.NoError(t, err)
+	_, err = c.SendRawTransaction("0x00")
+	assert.ErrorContains(t, err, "no write nodes available")
+	assert.Equal(t, int32(0), otherNet.sent.Load())
+}
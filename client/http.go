@@ -2,14 +2,21 @@ package client
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 type HttpError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Status  string `json:"states"`
+	// Err is the typed error parsed from the response body, such as a *TxRejectedError or *RevisionError.
+	// It is nil when the body was not recognised.
+	Err error `json:"-"`
+
+	retryAfter time.Duration
 }
 
 var ErrNotFound = &HttpError{Code: 404, Status: "not found", Message: "resource not found"}
@@ -34,9 +41,28 @@ func newHttpError(resp *http.Response) *HttpError {
 		Code:    resp.StatusCode,
 		Status:  resp.Status,
 		Message: message,
+		Err:     parseError(resp.StatusCode, message),
+
+		retryAfter: parseRetryAfter(resp.Header),
+	}
+}
+
+// NewHttpError creates the error returned by *Client when the node answers with the given status code and body,
+// for example 403 and "tx rejected: known tx". It lets other implementations of Backend fail the same way.
+func NewHttpError(code int, body string) *HttpError {
+	return &HttpError{
+		Code:    code,
+		Status:  fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Message: body,
+		Err:     parseError(code, body),
 	}
 }
 
+// Unwrap returns the typed error parsed from the response body, allowing errors.Is and errors.As to inspect it.
+func (e *HttpError) Unwrap() error {
+	return e.Err
+}
+
 func (e *HttpError) Is(target error) bool {
 	var t *HttpError
 	ok := errors.As(target, &t)
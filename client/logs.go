@@ -13,6 +13,8 @@ type FilterRange struct {
 type FilterOptions struct {
 	Offset *int64 `json:"offset,omitempty"`
 	Limit  *int64 `json:"limit,omitempty"`
+	// IncludeIndexes asks the node to fill LogMeta.TxIndex and LogMeta.LogIndex. Older nodes ignore it.
+	IncludeIndexes *bool `json:"includeIndexes,omitempty"`
 }
 
 type LogMeta struct {
@@ -22,4 +24,9 @@ type LogMeta struct {
 	TxID        common.Hash    `json:"txID"`
 	TxOrigin    common.Address `json:"txOrigin"`
 	ClauseIndex int64          `json:"clauseIndex"`
+	// TxIndex is the position of the transaction in its block. It is set when FilterOptions.IncludeIndexes is.
+	TxIndex *int64 `json:"txIndex,omitempty"`
+	// LogIndex is the position of the log among the logs of the same kind in its block. With the block ID, it
+	// identifies the log. It is set when FilterOptions.IncludeIndexes is.
+	LogIndex *int64 `json:"logIndex,omitempty"`
 }
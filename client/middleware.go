@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Operation is a logical call of the client, such as "FilterEvents" or "SendTransaction".
+type Operation struct {
+	// Name is the name of the client method, without the "WithContext" suffix.
+	Name string
+	// Request is the request sent to the node. Middleware may modify it, for example to add headers,
+	// before calling the next handler.
+	Request *http.Request
+	// Result is the decoded response, set once the next handler returns without error.
+	Result any
+	// Attempts is the number of requests sent to the node, including retries.
+	Attempts int
+}
+
+// Handler executes an operation.
+type Handler func(ctx context.Context, op *Operation) error
+
+// Middleware wraps the execution of every operation of a client. It sees an operation once,
+// retries and rate limiting happen inside the next handler.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to the client. The first middleware is the outermost one.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// execute runs an operation through the middleware of the client. The innermost handler sends the
+// request, with retries, and decodes the response.
+func execute[T any](
+	ctx context.Context,
+	c *Client,
+	name string,
+	req *http.Request,
+	retryable bool,
+	send func(req *http.Request, attempt int) (*T, error),
+) (*T, error) {
+	var result *T
+	handler := func(ctx context.Context, op *Operation) error {
+		req := op.Request.WithContext(ctx)
+		res, err := withRetry(ctx, c, retryable, func(attempt int) (*T, error) {
+			op.Attempts = attempt + 1
+			return send(req, attempt)
+		})
+		if err != nil {
+			return err
+		}
+		result = res
+		op.Result = res
+		return nil
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+
+	if err := handler(ctx, &Operation{Name: name, Request: req}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetHeader sets a header on every request, for example an API key.
+func SetHeader(key, value string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			op.Request.Header.Set(key, value)
+			return next(ctx, op)
+		}
+	}
+}
+
+// BearerToken authenticates every request with the given bearer token.
+func BearerToken(token string) Middleware {
+	return SetHeader("Authorization", "Bearer "+token)
+}
+
+// UserAgent sets the user agent of every request.
+func UserAgent(userAgent string) Middleware {
+	return SetHeader("User-Agent", userAgent)
+}
+
+// Logger logs every operation with the given logger. Successful operations are logged at debug level,
+// failed ones at warn level.
+func Logger(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			start := time.Now()
+			err := next(ctx, op)
+
+			attrs := []slog.Attr{
+				slog.String("op", op.Name),
+				slog.String("method", op.Request.Method),
+				slog.String("path", op.Request.URL.Path),
+				slog.Duration("duration", time.Since(start)),
+				slog.Int("attempts", op.Attempts),
+			}
+			level := slog.LevelDebug
+			if err != nil {
+				level = slog.LevelWarn
+				attrs = append(attrs, slog.Any("error", err))
+			}
+			logger.LogAttrs(ctx, level, "thor request", attrs...)
+			return err
+		}
+	}
+}
+
+// OperationStats are the counters collected by Metrics for an operation.
+type OperationStats struct {
+	Calls        uint64
+	Errors       uint64
+	Retries      uint64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// MeanLatency returns the average latency of the calls, including failed ones.
+func (s OperationStats) MeanLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Calls)
+}
+
+// Metrics collects call, error and latency counters per operation.
+// It is safe for concurrent use and can be shared by several clients.
+type Metrics struct {
+	mu    sync.Mutex
+	stats map[string]*OperationStats
+}
+
+// NewMetrics creates an empty metrics collector. Use Middleware to attach it to a client.
+func NewMetrics() *Metrics {
+	return &Metrics{stats: make(map[string]*OperationStats)}
+}
+
+// Middleware returns the middleware recording the operations of a client.
+func (m *Metrics) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			start := time.Now()
+			err := next(ctx, op)
+			m.record(op, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+func (m *Metrics) record(op *Operation, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.stats[op.Name]
+	if !ok {
+		stats = &OperationStats{}
+		m.stats[op.Name] = stats
+	}
+	stats.Calls++
+	if err != nil {
+		stats.Errors++
+	}
+	if op.Attempts > 1 {
+		stats.Retries += uint64(op.Attempts - 1)
+	}
+	stats.TotalLatency += latency
+	stats.MaxLatency = max(stats.MaxLatency, latency)
+}
+
+// Snapshot returns a copy of the counters, keyed by operation name.
+func (m *Metrics) Snapshot() map[string]OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]OperationStats, len(m.stats))
+	for name, stats := range m.stats {
+		snapshot[name] = *stats
+	}
+	return snapshot
+}
+
+// Reset clears the counters.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats = make(map[string]*OperationStats)
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_Order(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "thorgo-test", r.Header.Get("User-Agent"))
+		_ = json.NewEncoder(w).Encode(Account{HasCode: true})
+	}))
+	defer server.Close()
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				calls = append(calls, name+" "+op.Name)
+				err := next(ctx, op)
+				assert.IsType(t, &Account{}, op.Result)
+				return err
+			}
+		}
+	}
+
+	c := &Client{client: server.Client(), url: server.URL}
+	WithMiddleware(trace("outer"), BearerToken("secret"), UserAgent("thorgo-test"), trace("inner"))(c)
+
+	acc, err := c.Account(common.Address{})
+	assert.NoError(t, err)
+	assert.True(t, acc.HasCode)
+	assert.Equal(t, []string{"outer Account", "inner Account"}, calls)
+}
+
+func TestMiddleware_MetricsAndLogger(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blocks/best" && attempts.Add(1) == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/blocks/best" {
+			_ = json.NewEncoder(w).Encode(Block{Number: 10})
+			return
+		}
+		http.Error(w, "revision: not found", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	metrics := NewMetrics()
+	c := &Client{client: server.Client(), url: server.URL, retry: fastRetry}
+	WithMiddleware(metrics.Middleware(), Logger(logger))(c)
+
+	_, err := c.BestBlock()
+	assert.NoError(t, err)
+	_, err = c.Block(RevisionNumber(1234))
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, uint64(1), snapshot["BestBlock"].Calls)
+	assert.Equal(t, uint64(0), snapshot["BestBlock"].Errors)
+	assert.Equal(t, uint64(1), snapshot["BestBlock"].Retries)
+	assert.Equal(t, uint64(1), snapshot["Block"].Errors)
+	assert.Positive(t, snapshot["Block"].MeanLatency())
+
+	assert.Contains(t, logs.String(), `"level":"DEBUG","msg":"thor request","op":"BestBlock"`)
+	assert.Contains(t, logs.String(), `"level":"WARN","msg":"thor request","op":"Block"`)
+
+	metrics.Reset()
+	assert.Empty(t, metrics.Snapshot())
+}
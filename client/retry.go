@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithRetry retries failed requests according to the given policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimiter makes every request of the client wait for a token of the given limiter.
+// The same limiter can be given to several clients to share a single budget.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Queries, inspections and log filters don't change the state of the chain and are always safe to retry.
+// Transactions are retried too: a node which already received the transaction rejects it as "known tx",
+// so a retry rejected this way is treated as successfully sent. Raw transactions which can't be decoded
+// are never retried, since their ID is unknown.
+//
+// Requests are retried on timeouts, refused or dropped connections, "429 Too Many Requests" and 5xx responses.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. It doubles after every attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts, including waits requested by the node with "Retry-After".
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy makes up to 4 attempts, waiting from 200ms up to 5s between them.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// backoff returns the wait before the given retry, with jitter so that concurrent clients don't retry in lockstep.
+func (p RetryPolicy) backoff(retry int, err error) time.Duration {
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) && httpErr.retryAfter > 0 {
+		return min(httpErr.retryAfter, p.MaxBackoff)
+	}
+
+	delay := p.InitialBackoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxBackoff)
+	if delay <= 0 {
+		return 0
+	}
+	// wait between half and all of the computed backoff
+	// #nosec G404 -- jitter doesn't need a secure source
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// isRetryable reports whether the request may succeed if it is sent again.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusTooManyRequests || httpErr.Code >= http.StatusInternalServerError
+	}
+	// only failures of the connection are retried, not invalid URLs, unsupported schemes or TLS errors
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// withRetry calls send until it succeeds, the error is not retryable or the attempts are exhausted.
+// The rate limiter is consulted before every attempt.
+func withRetry[T any](ctx context.Context, c *Client, retryable bool, send func(attempt int) (*T, error)) (*T, error) {
+	attempts := 1
+	if retryable && c.retry.MaxAttempts > 1 {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			wait := time.NewTimer(c.retry.backoff(attempt, lastErr))
+			select {
+			case <-ctx.Done():
+				// the caller ended the call, the error of the last attempt only explains why it was retried
+				wait.Stop()
+				return nil, errors.Join(ctx.Err(), lastErr)
+			case <-wait.C:
+			}
+		}
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := send(attempt)
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+		if !isRetryable(ctx, err) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// parseRetryAfter reads the "Retry-After" header, which is either a number of seconds or a date.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// RateLimiter is a token bucket limiting the rate of requests sent to the nodes.
+// It is safe for concurrent use and can be shared by several clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows perSecond requests per second on average, and bursts of up to burst requests.
+// The rate must be positive.
+func NewRateLimiter(perSecond float64, burst int) (*RateLimiter, error) {
+	if !(perSecond > 0) {
+		return nil, fmt.Errorf("invalid rate limit: %v requests per second", perSecond)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// Wait blocks until a request is allowed or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// take the token now, the balance goes negative when the caller has to wait for it
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// give the reserved token back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
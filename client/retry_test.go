@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+func TestRetry_Idempotent(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Account{HasCode: true})
+	}))
+	defer server.Close()
+
+	c := &Client{client: server.Client(), url: server.URL, retry: fastRetry}
+	acc, err := c.Account(common.Address{})
+	assert.NoError(t, err)
+	assert.True(t, acc.HasCode)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestRetry_NotRetryable(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.Error(w, "revision: invalid prefix", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	c := &Client{client: server.Client(), url: server.URL, retry: fastRetry}
+	_, err := c.Account(common.Address{})
+	assert.ErrorIs(t, err, ErrInvalidRevision)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestRetry_CancelledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	c := &Client{client: server.Client(), url: server.URL, retry: retry}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.AccountWithContext(ctx, common.Address{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+}
+
+func TestRetry_KnownTransaction(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// the node received the transaction but the response was lost
+			http.Error(w, "gateway timeout", http.StatusGatewayTimeout)
+			return
+		}
+		http.Error(w, "tx rejected: known tx", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	trx := new(tx.Builder).ChainTag(1).Nonce(1).Build()
+	c := &Client{client: server.Client(), url: server.URL, retry: fastRetry}
+	res, err := c.SendTransaction(trx)
+	assert.NoError(t, err)
+	assert.Equal(t, trx.ID(), res.ID)
+	assert.Equal(t, int32(2), attempts.Load())
+
+	// a transaction known on the first attempt was not sent by this call
+	attempts.Store(1)
+	_, err = c.SendTransaction(trx)
+	assert.ErrorIs(t, err, ErrKnownTx)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter, err := NewRateLimiter(20, 1)
+	assert.NoError(t, err)
+	start := time.Now()
+	for range 3 {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}
+
+func TestRateLimiter_InvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		_, err := NewRateLimiter(rate, 1)
+		assert.Error(t, err)
+	}
+}
+
+func TestRetry_TransportErrors(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+
+	tests := []struct {
+		name      string
+		url       string
+		retryable bool
+	}{
+		{"refused connection", closed.URL, true},
+		{"unsupported scheme", "ftp://localhost", false},
+		{"untrusted certificate", tlsServer.URL, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.NoError(t, err)
+			_, err = http.DefaultClient.Do(req)
+			assert.Error(t, err)
+			assert.Equal(t, tt.retryable, isRetryable(context.Background(), err))
+		})
+	}
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer slow.Close()
+	_, err := (&http.Client{Timeout: time.Millisecond}).Get(slow.URL)
+	assert.True(t, isRetryable(context.Background(), err))
+}
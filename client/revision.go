@@ -0,0 +1,85 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Revision selects the block a query is answered at: a block ID, a block number or one of the named revisions.
+// The zero value is the best block.
+type Revision struct {
+	value string
+}
+
+var (
+	// RevisionBest is the best block of the canonical chain.
+	RevisionBest = Revision{value: "best"}
+	// RevisionJustified is the latest justified block.
+	RevisionJustified = Revision{value: "justified"}
+	// RevisionFinalized is the latest finalized block.
+	RevisionFinalized = Revision{value: "finalized"}
+	// RevisionNext is the block being packed after the best block. It is accepted by the account, code, storage
+	// and inspect queries only, for example to simulate a transaction as it would execute in the next block.
+	RevisionNext = Revision{value: "next"}
+)
+
+// RevisionID selects the block with the given ID.
+func RevisionID(id common.Hash) Revision {
+	return Revision{value: id.Hex()}
+}
+
+// RevisionNumber selects the block of the canonical chain with the given number.
+func RevisionNumber(number uint32) Revision {
+	return Revision{value: strconv.FormatUint(uint64(number), 10)}
+}
+
+// ParseRevision parses a revision as written in the API of Thor: a block ID, a decimal block number or one of
+// "best", "justified", "finalized" and "next". The empty string is the best block.
+func ParseRevision(s string) (Revision, error) {
+	switch s {
+	case "":
+		return Revision{}, nil
+	case RevisionBest.value, RevisionJustified.value, RevisionFinalized.value, RevisionNext.value:
+		return Revision{value: s}, nil
+	}
+	if strings.HasPrefix(s, "0x") {
+		id, err := common.ParseHexOrString(s)
+		if err != nil || len(id) != common.HashLength {
+			return Revision{}, fmt.Errorf("%w: %q", ErrInvalidRevision, s)
+		}
+		return RevisionID(common.BytesToHash(id)), nil
+	}
+	number, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return Revision{}, fmt.Errorf("%w: %q", ErrInvalidRevision, s)
+	}
+	return RevisionNumber(uint32(number)), nil
+}
+
+// ID returns the block ID of the revision, if it selects a block by ID.
+func (r Revision) ID() (common.Hash, bool) {
+	if !strings.HasPrefix(r.value, "0x") {
+		return common.Hash{}, false
+	}
+	return common.HexToHash(r.value), true
+}
+
+// Number returns the block number of the revision, if it selects a block by number.
+func (r Revision) Number() (uint32, bool) {
+	number, err := strconv.ParseUint(r.value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(number), true
+}
+
+// String returns the revision as written in the API of Thor.
+func (r Revision) String() string {
+	if r.value == "" {
+		return RevisionBest.value
+	}
+	return r.value
+}
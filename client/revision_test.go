@@ -0,0 +1,49 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRevision(t *testing.T) {
+	id := common.HexToHash("0x0000000a00000000000000000000000000000000000000000000000000000001")
+
+	for s, want := range map[string]Revision{
+		"":          RevisionBest,
+		"best":      RevisionBest,
+		"justified": RevisionJustified,
+		"finalized": RevisionFinalized,
+		"next":      RevisionNext,
+		"10":        RevisionNumber(10),
+		id.Hex():    RevisionID(id),
+	} {
+		rev, err := ParseRevision(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, want.String(), rev.String(), s)
+	}
+
+	for _, s := range []string{"abc", "-1", "4294967296", "0x1234"} {
+		_, err := ParseRevision(s)
+		assert.ErrorIs(t, err, ErrInvalidRevision, s)
+	}
+}
+
+func TestRevision_Accessors(t *testing.T) {
+	id := common.HexToHash("0x01")
+
+	got, ok := RevisionID(id).ID()
+	assert.True(t, ok)
+	assert.Equal(t, id, got)
+	_, ok = RevisionID(id).Number()
+	assert.False(t, ok)
+
+	number, ok := RevisionNumber(7).Number()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(7), number)
+	_, ok = RevisionFinalized.Number()
+	assert.False(t, ok)
+
+	assert.Equal(t, "best", Revision{}.String())
+}
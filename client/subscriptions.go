@@ -0,0 +1,397 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+)
+
+// BlockMessage is a block received from the block subscription.
+// Obsolete is true when the block has been removed from the canonical chain by a re-organisation.
+type BlockMessage struct {
+	Block
+	Obsolete bool `json:"obsolete"`
+}
+
+// EventMessage is an event log received from the event subscription.
+type EventMessage struct {
+	EventLog
+	Obsolete bool `json:"obsolete"`
+}
+
+// TransferMessage is a transfer log received from the transfer subscription.
+type TransferMessage struct {
+	TransferLog
+	Obsolete bool `json:"obsolete"`
+}
+
+// BeatMessage is a lightweight block summary received from the beat subscription.
+// Bloom and K describe a bloom filter of the addresses and topics touched by the block.
+type BeatMessage struct {
+	Number      int64         `json:"number"`
+	ID          common.Hash   `json:"id"`
+	ParentID    common.Hash   `json:"parentID"`
+	Timestamp   int64         `json:"timestamp"`
+	TxsFeatures int64         `json:"txsFeatures"`
+	GasLimit    int64         `json:"gasLimit"`
+	Bloom       hexutil.Bytes `json:"bloom"`
+	K           uint32        `json:"k"`
+	Obsolete    bool          `json:"obsolete"`
+}
+
+// TxPoolMessage is a pending transaction ID received from the txpool subscription.
+type TxPoolMessage struct {
+	ID common.Hash `json:"id"`
+}
+
+// Subscription delivers messages from a Thor websocket endpoint.
+// It reconnects automatically, resuming from the last received block, until the context
+// is done or Unsubscribe is called. Events and transfers resume from the last block whose logs were all
+// received, and the logs of the next block which were already delivered aren't delivered again.
+// The channel returned by C is closed once the subscription ends.
+type Subscription[T any] struct {
+	messages chan T
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// C returns the channel on which messages are delivered.
+func (s *Subscription[T]) C() <-chan T {
+	return s.messages
+}
+
+// Err returns the error that terminated the subscription, if any.
+// It returns nil while the subscription is active or when it was ended by the caller.
+func (s *Subscription[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Unsubscribe closes the connection and waits for the subscription to end.
+func (s *Subscription[T]) Unsubscribe() {
+	s.cancel()
+	<-s.done
+}
+
+// SubscribeBlocks subscribes to new blocks. If pos is not nil, the subscription starts after the given block ID.
+func (c *Client) SubscribeBlocks(ctx context.Context, pos *common.Hash) (*Subscription[BlockMessage], error) {
+	return subscribe(ctx, c, "SubscribeBlocks", "/subscriptions/block", url.Values{}, pos, false,
+		func(m *BlockMessage) common.Hash {
+			return m.ID
+		})
+}
+
+// SubscribeEvents subscribes to event logs matching the given criteria.
+// If pos is not nil, the subscription starts after the given block ID.
+func (c *Client) SubscribeEvents(
+	ctx context.Context,
+	criteria EventCriteria,
+	pos *common.Hash,
+) (*Subscription[EventMessage], error) {
+	query := url.Values{}
+	if criteria.Address != nil {
+		query.Set("addr", criteria.Address.Hex())
+	}
+	topics := []*common.Hash{criteria.Topic0, criteria.Topic1, criteria.Topic2, criteria.Topic3, criteria.Topic4}
+	for i, topic := range topics {
+		if topic != nil {
+			query.Set(fmt.Sprintf("t%d", i), topic.Hex())
+		}
+	}
+	return subscribe(ctx, c, "SubscribeEvents", "/subscriptions/event", query, pos, true,
+		func(m *EventMessage) common.Hash {
+			return m.Meta.BlockID
+		})
+}
+
+// SubscribeTransfers subscribes to VET transfers matching the given criteria.
+// If pos is not nil, the subscription starts after the given block ID.
+func (c *Client) SubscribeTransfers(
+	ctx context.Context,
+	criteria TransferCriteria,
+	pos *common.Hash,
+) (*Subscription[TransferMessage], error) {
+	query := url.Values{}
+	if criteria.TxOrigin != nil {
+		query.Set("txOrigin", criteria.TxOrigin.Hex())
+	}
+	if criteria.Sender != nil {
+		query.Set("sender", criteria.Sender.Hex())
+	}
+	if criteria.Recipient != nil {
+		query.Set("recipient", criteria.Recipient.Hex())
+	}
+	return subscribe(ctx, c, "SubscribeTransfers", "/subscriptions/transfer", query, pos, true,
+		func(m *TransferMessage) common.Hash {
+			return m.Meta.BlockID
+		})
+}
+
+// SubscribeBeats subscribes to block beats. If pos is not nil, the subscription starts after the given block ID.
+func (c *Client) SubscribeBeats(ctx context.Context, pos *common.Hash) (*Subscription[BeatMessage], error) {
+	return subscribe(ctx, c, "SubscribeBeats", "/subscriptions/beat2", url.Values{}, pos, false,
+		func(m *BeatMessage) common.Hash {
+			return m.ID
+		})
+}
+
+// SubscribeTxPool subscribes to the IDs of transactions entering the node's transaction pool.
+func (c *Client) SubscribeTxPool(ctx context.Context) (*Subscription[TxPoolMessage], error) {
+	return subscribe(ctx, c, "SubscribeTxPool", "/subscriptions/txpool", url.Values{}, nil, false,
+		func(*TxPoolMessage) common.Hash {
+			return common.Hash{}
+		})
+}
+
+const (
+	minReconnectDelay = 500 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
+)
+
+// subscribe dials the endpoint once so that configuration errors are returned to the caller,
+// then keeps the subscription alive in the background.
+// position extracts the block ID of a message, which is used as "pos" when reconnecting.
+// grouped reports whether a block may have several messages, as it has several logs.
+func subscribe[T any](
+	ctx context.Context,
+	c *Client,
+	op string,
+	path string,
+	query url.Values,
+	pos *common.Hash,
+	grouped bool,
+	position func(*T) common.Hash,
+) (*Subscription[T], error) {
+	conn, err := dialSubscription(ctx, c, op, path, query, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &Subscription[T]{
+		messages: make(chan T),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(sub.done)
+		defer close(sub.messages)
+		defer cancel()
+
+		cur := &cursor{pos: pos, grouped: grouped}
+		delay := minReconnectDelay
+		for {
+			delivered, err := readSubscription(ctx, conn, sub.messages, position, cur)
+			conn.Close()
+			if delivered {
+				delay = minReconnectDelay
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil && !isTemporary(err) {
+				sub.fail(err)
+				return
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				delay = min(delay*2, maxReconnectDelay)
+
+				conn, err = dialSubscription(ctx, c, op, path, query, cur.pos)
+				if err == nil {
+					cur.rewind()
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if !isTemporary(err) {
+					sub.fail(err)
+					return
+				}
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+func (s *Subscription[T]) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// cursor tracks the position a subscription resumes from.
+//
+// The logs of a block are sent as several messages, and the connection may drop before all of them are received.
+// The position therefore only moves to a block once a message of a following block is received. After
+// reconnecting, the node sends the messages of the block being read again, and those already delivered are skipped.
+type cursor struct {
+	// pos is the block the subscription resumes after, nil to resume from the best block.
+	pos     *common.Hash
+	grouped bool
+	// block is the block of the last delivered message, and messages the messages of it delivered.
+	block    common.Hash
+	messages []json.RawMessage
+	// replayed is the number of messages of block sent again since reconnecting.
+	replayed int
+}
+
+// rewind expects the messages of the block being read to be sent again.
+func (c *cursor) rewind() {
+	c.replayed = 0
+}
+
+// seen reports whether a message was already delivered before reconnecting.
+func (c *cursor) seen(raw json.RawMessage) bool {
+	if c.replayed < len(c.messages) && bytes.Equal(raw, c.messages[c.replayed]) {
+		c.replayed++
+		return true
+	}
+	// the messages sent again differ from those delivered, after a re-organisation for example
+	c.replayed = len(c.messages)
+	return false
+}
+
+// advance records a delivered message of the given block.
+func (c *cursor) advance(block common.Hash, raw json.RawMessage) {
+	if block == (common.Hash{}) {
+		return
+	}
+	if !c.grouped {
+		c.pos = &block
+		return
+	}
+	if block != c.block {
+		if c.block != (common.Hash{}) {
+			complete := c.block
+			c.pos = &complete
+		}
+		c.block, c.messages = block, nil
+	}
+	c.messages = append(c.messages, raw)
+	c.replayed = len(c.messages)
+}
+
+// readSubscription forwards messages until the connection fails or the context is done.
+// It reports whether a message was delivered.
+func readSubscription[T any](
+	ctx context.Context,
+	conn *websocket.Conn,
+	out chan<- T,
+	position func(*T) common.Hash,
+	cur *cursor,
+) (bool, error) {
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	delivered := false
+	for {
+		var raw json.RawMessage
+		if err := conn.ReadJSON(&raw); err != nil {
+			return delivered, err
+		}
+		if cur.seen(raw) {
+			continue
+		}
+		var msg T
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return delivered, err
+		}
+		select {
+		case out <- msg:
+			delivered = true
+			cur.advance(position(&msg), raw)
+		case <-ctx.Done():
+			return delivered, ctx.Err()
+		}
+	}
+}
+
+// dialSubscription opens the websocket connection. The handshake goes through the middleware of the client,
+// so that headers such as credentials are sent with it.
+func dialSubscription(
+	ctx context.Context,
+	c *Client,
+	op string,
+	path string,
+	query url.Values,
+	pos *common.Hash,
+) (*websocket.Conn, error) {
+	if pos != nil {
+		query = cloneValues(query)
+		query.Set("pos", pos.Hex())
+	}
+
+	base := c.url
+	if t, ok := c.client.Transport.(*failoverTransport); ok {
+		base = t.readURL()
+	}
+	endpoint := base + path
+	switch {
+	case strings.HasPrefix(endpoint, "https://"):
+		endpoint = "wss://" + strings.TrimPrefix(endpoint, "https://")
+	case strings.HasPrefix(endpoint, "http://"):
+		endpoint = "ws://" + strings.TrimPrefix(endpoint, "http://")
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	return execute(ctx, c, op, req, false, func(req *http.Request, _ int) (*websocket.Conn, error) {
+		conn, res, err := websocket.DefaultDialer.DialContext(req.Context(), req.URL.String(), req.Header)
+		if err != nil {
+			if res != nil && errors.Is(err, websocket.ErrBadHandshake) {
+				return nil, newHttpError(res)
+			}
+			return nil, err
+		}
+		return conn, nil
+	})
+}
+
+// isTemporary reports whether reconnecting may recover from the error.
+// Requests rejected by the node, such as an invalid or too old "pos", are permanent.
+func isTemporary(err error) bool {
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusTooManyRequests || httpErr.Code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values))
+	for k, v := range values {
+		cloned[k] = append([]string(nil), v...)
+	}
+	return cloned
+}
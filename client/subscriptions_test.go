@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SubscribeBlocksResumes(t *testing.T) {
+	blocks := []BlockMessage{
+		{Block: Block{Number: 1, ID: common.HexToHash("0x01")}},
+		{Block: Block{Number: 2, ID: common.HexToHash("0x02")}},
+		{Block: Block{Number: 3, ID: common.HexToHash("0x03")}},
+	}
+	positions := make(chan string, 2)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		positions <- r.URL.Query().Get("pos")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// the first connection drops after two blocks, the second one resumes
+		pending := blocks[:2]
+		if r.URL.Query().Get("pos") != "" {
+			pending = blocks[2:]
+		}
+		for _, b := range pending {
+			_ = conn.WriteJSON(b)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{client: server.Client(), url: server.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := c.SubscribeBlocks(ctx, nil)
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, expected := range blocks {
+		select {
+		case msg := <-sub.C():
+			assert.Equal(t, expected.ID, msg.ID)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for blocks")
+		}
+	}
+
+	assert.Equal(t, "", <-positions)
+	assert.Equal(t, blocks[1].ID.Hex(), <-positions)
+}
+
+func TestClient_SubscribeEventsResumesMidBlock(t *testing.T) {
+	event := func(block string, clause int64) EventMessage {
+		return EventMessage{EventLog: EventLog{Meta: LogMeta{BlockID: common.HexToHash(block), ClauseIndex: clause}}}
+	}
+	events := []EventMessage{event("0x01", 0), event("0x01", 1), event("0x02", 0), event("0x02", 1), event("0x03", 0)}
+	positions := make(chan string, 2)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		positions <- r.URL.Query().Get("pos")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// the first connection drops after the first event of block 2, the second one resumes after block 1
+		pending := events[:3]
+		if r.URL.Query().Get("pos") == common.HexToHash("0x01").Hex() {
+			pending = events[2:]
+		}
+		for _, e := range pending {
+			_ = conn.WriteJSON(e)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{client: server.Client(), url: server.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := c.SubscribeEvents(ctx, EventCriteria{}, nil)
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, expected := range events {
+		select {
+		case msg := <-sub.C():
+			assert.Equal(t, expected.Meta, msg.Meta)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	assert.Equal(t, "", <-positions)
+	assert.Equal(t, common.HexToHash("0x01").Hex(), <-positions)
+}
+
+func TestClient_SubscribeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "pos: backtrace limit exceeded", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c := &Client{client: server.Client(), url: server.URL}
+	pos := common.HexToHash("0x01")
+	_, err := c.SubscribeBlocks(context.Background(), &pos)
+
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusForbidden, httpErr.Code)
+}
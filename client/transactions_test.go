@@ -42,3 +42,25 @@ func TestClient_SendTransaction(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, signedTx.ID().String(), tx.ID.String())
 }
+
+func TestClient_SendTransactionChainTagMismatch(t *testing.T) {
+	account1 := solo.Keys()[0]
+	account2Addr := crypto.PubkeyToAddress(solo.Keys()[1].PublicKey)
+
+	txBody := new(tx.Builder).
+		Gas(3_000_000).
+		ChainTag(client.ChainTag() + 1).
+		Expiration(100000000).
+		BlockRef(tx.NewBlockRef(0)).
+		Nonce(tx.Nonce()).
+		Clause(tx.NewClause(&account2Addr).WithValue(big.NewInt(1000))).
+		Build()
+
+	signingHash := txBody.SigningHash()
+	signature, err := crypto.Sign(signingHash[:], account1)
+	assert.NoError(t, err)
+
+	_, err = client.SendTransaction(txBody.WithSignature(signature))
+	assert.ErrorIs(t, err, ErrBadTx)
+	assert.ErrorIs(t, err, ErrChainTagMismatch)
+}
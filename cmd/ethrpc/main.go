@@ -0,0 +1,71 @@
+// Command ethrpc serves the Ethereum JSON-RPC API of package ethrpc on top of a Thor node.
+//
+//	ethrpc -node https://mainnet.vechain.org -addr localhost:8545
+//
+// See package ethrpc for the methods served and how their semantics differ from Ethereum.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/ethrpc"
+)
+
+func main() {
+	node := flag.String("node", "http://localhost:8669", "URL of the Thor node")
+	addr := flag.String("addr", "localhost:8545", "address to serve the JSON-RPC API on")
+	var opts ethrpc.Options
+	flag.Int64Var(&opts.MaxBlockRange, "max-block-range", 100_000,
+		"maximum number of blocks of an eth_getLogs query, negative for no limit")
+	flag.IntVar(&opts.MaxLogs, "max-logs", 10_000, "maximum number of logs returned by eth_getLogs, negative for no limit")
+	flag.Parse()
+
+	if err := run(*node, *addr, opts); err != nil {
+		fmt.Fprintln(os.Stderr, "ethrpc:", err)
+		os.Exit(1)
+	}
+}
+
+func run(node, addr string, opts ethrpc.Options) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := client.NewWithContext(ctx, node, &http.Client{Timeout: 30 * time.Second})
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", node, err)
+	}
+	server, err := ethrpc.NewServer(c, opts)
+	if err != nil {
+		return err
+	}
+	defer server.Stop()
+
+	httpServer := &http.Server{Addr: addr, Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("serving the JSON-RPC API of %s on %s", node, addr)
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
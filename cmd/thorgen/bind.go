@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// binding is the model of the generated file.
+type binding struct {
+	Package     string
+	Type        string
+	ABI         string
+	Bin         string
+	Constructor *function
+	Calls       []*function
+	Transacts   []*function
+	Events      []*event
+	Structs     []*tuple
+}
+
+type function struct {
+	// Name is the Go name of the method, Original its name in the ABI.
+	Name     string
+	Original string
+	Sig      string
+	Payable  bool
+	Inputs   []*param
+	Outputs  []*param
+	// Result is the type returned by a call: the type of its output, or a struct of its outputs.
+	Result string
+}
+
+type event struct {
+	Name     string
+	Original string
+	Sig      string
+	Inputs   []*param
+}
+
+type param struct {
+	// Name is the Go name of a method parameter, Field the name of a struct field.
+	Name  string
+	Field string
+	Type  string
+	// Matcher is the type of the accepted values of an indexed event input, empty if it can't be matched by value.
+	Matcher string
+}
+
+type tuple struct {
+	Name   string
+	Sig    string
+	Fields []*param
+}
+
+// bind generates the Go binding of a contract, from its ABI in JSON and optionally its bytecode in hex.
+func bind(pkg, typeName string, abiJSON []byte, bin string) ([]byte, error) {
+	parsed, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("parse ABI: %w", err)
+	}
+	compact := new(bytes.Buffer)
+	if err := json.Compact(compact, abiJSON); err != nil {
+		return nil, fmt.Errorf("compact ABI: %w", err)
+	}
+	if bin != "" && !strings.HasPrefix(bin, "0x") {
+		bin = "0x" + bin
+	}
+
+	b := &binding{Package: pkg, Type: typeName, ABI: compact.String(), Bin: bin}
+	types := &typeMapper{prefix: typeName, tuples: make(map[string]*tuple)}
+
+	b.Constructor = &function{Payable: parsed.Constructor.IsPayable(), Inputs: types.params(parsed.Constructor.Inputs)}
+	b.Constructor.reserveValue()
+	for _, name := range sortedKeys(parsed.Methods) {
+		method := parsed.Methods[name]
+		fn := &function{
+			Name:     exported(method.Name),
+			Original: method.Name,
+			Sig:      method.Sig,
+			Payable:  method.IsPayable(),
+			Inputs:   types.params(method.Inputs),
+		}
+		fn.reserveValue()
+		if !method.IsConstant() {
+			b.Transacts = append(b.Transacts, fn)
+			continue
+		}
+		fn.Outputs = types.params(method.Outputs)
+		switch len(fn.Outputs) {
+		case 0:
+		case 1:
+			fn.Result = fn.Outputs[0].Type
+		default:
+			fn.Result = typeName + fn.Name + "Output"
+		}
+		b.Calls = append(b.Calls, fn)
+	}
+	for _, name := range sortedKeys(parsed.Events) {
+		ev := parsed.Events[name]
+		e := &event{Name: exported(ev.Name), Original: ev.Name, Sig: ev.Sig}
+		for i, input := range ev.Inputs {
+			p := &param{Name: paramName(input.Name, i), Field: fieldName(input.Name, i), Type: types.goType(input.Type)}
+			if input.Indexed {
+				switch input.Type.T {
+				case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy, abi.FunctionTy:
+					// only the hash of dynamic values is stored in the topic
+					if input.Type.T == abi.StringTy || input.Type.T == abi.BytesTy {
+						p.Matcher = p.Type
+					}
+					p.Type = "common.Hash"
+				default:
+					p.Matcher = p.Type
+				}
+			}
+			e.Inputs = append(e.Inputs, p)
+		}
+		b.Events = append(b.Events, e)
+	}
+	for _, key := range sortedKeys(types.tuples) {
+		b.Structs = append(b.Structs, types.tuples[key])
+	}
+
+	out := new(bytes.Buffer)
+	if err := bindingTemplate.Execute(out, b); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format binding: %w\n%s", err, out.String())
+	}
+	return formatted, nil
+}
+
+// reserveValue renames the inputs of a payable function colliding with the value parameter of its transactions.
+func (fn *function) reserveValue() {
+	if !fn.Payable {
+		return
+	}
+	for _, input := range fn.Inputs {
+		if input.Name == "value" {
+			input.Name += "Arg"
+		}
+	}
+}
+
+// typeMapper maps ABI types to Go types, and collects the structs of the tuples.
+type typeMapper struct {
+	prefix string
+	tuples map[string]*tuple
+}
+
+func (m *typeMapper) params(args abi.Arguments) []*param {
+	params := make([]*param, len(args))
+	for i, arg := range args {
+		params[i] = &param{Name: paramName(arg.Name, i), Field: fieldName(arg.Name, i), Type: m.goType(arg.Type)}
+	}
+	return params
+}
+
+func (m *typeMapper) goType(t abi.Type) string {
+	switch t.T {
+	case abi.AddressTy:
+		return "common.Address"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy:
+		return "string"
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.FunctionTy:
+		return "[24]byte"
+	case abi.IntTy, abi.UintTy:
+		switch t.Size {
+		case 8, 16, 32, 64:
+			if t.T == abi.IntTy {
+				return fmt.Sprintf("int%d", t.Size)
+			}
+			return fmt.Sprintf("uint%d", t.Size)
+		}
+		return "*big.Int"
+	case abi.SliceTy:
+		return "[]" + m.goType(*t.Elem)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]%s", t.Size, m.goType(*t.Elem))
+	case abi.TupleTy:
+		return m.tuple(t)
+	}
+	return "interface{}"
+}
+
+// tuple returns the name of the struct of a tuple, named after the struct of the contract source if known.
+func (m *typeMapper) tuple(t abi.Type) string {
+	sig := t.String()
+	if existing, ok := m.tuples[sig]; ok {
+		return existing.Name
+	}
+	name := t.TupleRawName
+	if name == "" {
+		name = fmt.Sprintf("Tuple%d", len(m.tuples))
+	}
+	name = exported(name)
+	if !strings.HasPrefix(name, m.prefix) {
+		name = m.prefix + name
+	}
+	s := &tuple{Name: name, Sig: sig}
+	m.tuples[sig] = s
+	for i, elem := range t.TupleElems {
+		s.Fields = append(s.Fields, &param{Field: fieldName(t.TupleRawNames[i], i), Type: m.goType(*elem)})
+	}
+	return s.Name
+}
+
+// reserved are the names used by the generated methods, which parameters can't take.
+var reserved = map[string]bool{
+	"c": true, "ctx": true, "manager": true, "backend": true, "sender": true, "clause": true,
+	"out": true, "result": true, "err": true, "txID": true, "parsed": true, "deployer": true, "contract": true,
+	"log": true, "event": true,
+}
+
+func paramName(name string, i int) string {
+	name = abi.ToCamelCase(name)
+	if name == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+	if reserved[name] || token.IsKeyword(name) {
+		name += "Arg"
+	}
+	return name
+}
+
+func fieldName(name string, i int) string {
+	if name == "" {
+		return fmt.Sprintf("Arg%d", i)
+	}
+	return abi.ToCamelCase(name)
+}
+
+func exported(name string) string {
+	return abi.ToCamelCase(name)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var bindingTemplate = template.Must(template.New("binding").Funcs(template.FuncMap{
+	"params": func(params []*param) string {
+		list := make([]string, len(params))
+		for i, p := range params {
+			list[i] = p.Name + " " + p.Type
+		}
+		return strings.Join(list, ", ")
+	},
+	"args": func(params []*param) string {
+		list := make([]string, len(params))
+		for i, p := range params {
+			list[i] = p.Name
+		}
+		return strings.Join(list, ", ")
+	},
+	// txParams are the parameters of a transaction, led by its value when the function is payable
+	"txParams": func(fn *function) string {
+		list := make([]string, 0, len(fn.Inputs)+1)
+		if fn.Payable {
+			list = append(list, "value *big.Int")
+		}
+		for _, p := range fn.Inputs {
+			list = append(list, p.Name+" "+p.Type)
+		}
+		return strings.Join(list, ", ")
+	},
+	"matchers": func(params []*param) string {
+		var list []string
+		for _, p := range params {
+			if p.Matcher != "" {
+				list = append(list, p.Name+" []"+p.Matcher)
+			}
+		}
+		return strings.Join(list, ", ")
+	},
+	"lead": func(s string) string {
+		if s == "" {
+			return ""
+		}
+		return ", " + s
+	},
+}).Parse(bindingSource))
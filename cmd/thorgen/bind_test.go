@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBind_Golden checks the bindings checked in under internal are up to date with the generator.
+func TestBind_Golden(t *testing.T) {
+	cases := []struct {
+		pkg, typeName string
+		bin           bool
+	}{
+		{"erc20", "ERC20", true},
+		{"store", "Store", false},
+	}
+	for _, c := range cases {
+		t.Run(c.pkg, func(t *testing.T) {
+			dir := filepath.Join("internal", c.pkg)
+			abiJSON, err := os.ReadFile(filepath.Join(dir, c.pkg+".abi"))
+			assert.NoError(t, err)
+			var bin string
+			if c.bin {
+				raw, err := os.ReadFile(filepath.Join(dir, c.pkg+".bin"))
+				assert.NoError(t, err)
+				bin = strings.TrimSpace(string(raw))
+			}
+
+			code, err := bind(c.pkg, c.typeName, abiJSON, bin)
+			assert.NoError(t, err)
+			golden, err := os.ReadFile(filepath.Join(dir, c.pkg+".go"))
+			assert.NoError(t, err)
+			assert.Equal(t, string(golden), string(code), "run go generate ./cmd/thorgen/...")
+		})
+	}
+}
+
+func TestBind_PayableConstructor(t *testing.T) {
+	abiJSON, err := os.ReadFile(filepath.Join("internal", "store", "store.abi"))
+	assert.NoError(t, err)
+
+	code, err := bind("store", "Store", abiJSON, "6080")
+	assert.NoError(t, err)
+	assert.Contains(t, string(code), `const StoreBin = "0x6080"`)
+	assert.Contains(t, string(code), "sender accounts.TxManager, value *big.Int, owner common.Address)")
+	assert.Contains(t, string(code), ".WithValue(value)\n")
+}
+
+func TestParseArtifact(t *testing.T) {
+	abiJSON := `[{"type":"function","name":"ping","inputs":[],"outputs":[]}]`
+
+	parsed, bin, err := parseArtifact([]byte(abiJSON))
+	assert.NoError(t, err)
+	assert.Equal(t, abiJSON, string(parsed))
+	assert.Empty(t, bin)
+
+	parsed, bin, err = parseArtifact([]byte(`{"contractName":"Ping","abi":` + abiJSON + `,"bytecode":"0x6080"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, abiJSON, string(parsed))
+	assert.Equal(t, "0x6080", bin)
+
+	_, _, err = parseArtifact([]byte(`{"contractName":"Ping"}`))
+	assert.Error(t, err)
+	_, _, err = parseArtifact([]byte(`abi`))
+	assert.Error(t, err)
+}
+
+func TestParamName(t *testing.T) {
+	assert.Equal(t, "owner", paramName("owner", 0))
+	assert.Equal(t, "arg1", paramName("", 1))
+	assert.Equal(t, "tokenId", paramName("_tokenId", 0))
+	assert.Equal(t, "typeArg", paramName("type", 0))
+	assert.Equal(t, "ctxArg", paramName("ctx", 0))
+}
@@ -0,0 +1,5 @@
+// Package erc20 is the binding generated by thorgen for an OpenZeppelin ERC20 token with a public mint function.
+// It is checked in to test the generated code against the simulated backend.
+package erc20
+
+//go:generate go run ../.. -abi erc20.abi -bin erc20.bin -pkg erc20 -type ERC20 -out erc20.go
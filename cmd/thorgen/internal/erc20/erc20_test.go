@@ -0,0 +1,70 @@
+package erc20_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/darrenvechain/thorgo"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/cmd/thorgen/internal/erc20"
+	"github.com/darrenvechain/thorgo/simulated"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestERC20(t *testing.T) {
+	ctx := context.Background()
+	backend, err := simulated.NewBackend(nil, simulated.WithAutoMine())
+	assert.NoError(t, err)
+	sender := txmanager.FromPK(solo.Keys()[0], thorgo.FromClient(backend))
+
+	token, _, err := erc20.DeployERC20(ctx, backend, sender, "MyERC20", "ERC20")
+	assert.NoError(t, err)
+
+	name, err := token.Name(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "MyERC20", name)
+	symbol, err := token.Symbol(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ERC20", symbol)
+
+	recipient := common.HexToAddress("0x1234")
+	mint, err := token.Mint(ctx, sender, sender.Address(), big.NewInt(1000))
+	assert.NoError(t, err)
+	receipt, err := mint.Wait()
+	assert.NoError(t, err)
+	assert.False(t, receipt.Reverted)
+
+	clause, err := token.TransferClause(recipient, big.NewInt(300))
+	assert.NoError(t, err)
+	assert.Equal(t, token.Contract.Address, *clause.To())
+	transfer, err := token.Transfer(ctx, sender, recipient, big.NewInt(300))
+	assert.NoError(t, err)
+	receipt, err = transfer.Wait()
+	assert.NoError(t, err)
+	assert.False(t, receipt.Reverted)
+
+	balance, err := token.BalanceOf(ctx, recipient)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(300), balance)
+
+	// the logs of the transfer to the recipient, and not of the mint
+	criteria, err := token.TransferCriteria(nil, []common.Address{recipient})
+	assert.NoError(t, err)
+	logs, err := backend.FilterEventsWithContext(ctx, &client.EventFilter{Criteria: &criteria})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+
+	event, err := token.DecodeTransfer(logs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, sender.Address(), event.From)
+	assert.Equal(t, recipient, event.To)
+	assert.Equal(t, big.NewInt(300), event.Value)
+	assert.Equal(t, logs[0], event.Raw)
+
+	_, err = token.DecodeApproval(logs[0])
+	assert.Error(t, err)
+}
@@ -0,0 +1,5 @@
+// Package store is the binding generated by thorgen for an ABI using tuples, payable functions, overloads and
+// multiple outputs. It is checked in to ensure the generated code compiles.
+package store
+
+//go:generate go run ../.. -abi store.abi -pkg store -out store.go
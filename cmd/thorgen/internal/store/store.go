@@ -0,0 +1,188 @@
+// Code generated by thorgen. DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/transactions"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = accounts.NewContract
+	_ = client.EventLog{}
+	_ = tx.NewClause
+	_ = transactions.New
+	_ = abi.ConvertType
+	_ = common.Big1
+)
+
+// StoreABI is the ABI of the Store contract.
+const StoreABI = "[{\"type\":\"constructor\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"function\",\"name\":\"get\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"key\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"tuple\",\"internalType\":\"struct Store.Item\",\"components\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"price\",\"type\":\"uint128\",\"internalType\":\"uint128\"},{\"name\":\"tags\",\"type\":\"string[]\",\"internalType\":\"string[]\"}]}]},{\"type\":\"function\",\"name\":\"stats\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"count\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"updated\",\"type\":\"bool\",\"internalType\":\"bool\"}]},{\"type\":\"function\",\"name\":\"ping\",\"stateMutability\":\"pure\",\"inputs\":[],\"outputs\":[]},{\"type\":\"function\",\"name\":\"put\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"key\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"item\",\"type\":\"tuple\",\"internalType\":\"struct Store.Item\",\"components\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"price\",\"type\":\"uint128\",\"internalType\":\"uint128\"},{\"name\":\"tags\",\"type\":\"string[]\",\"internalType\":\"string[]\"}]},{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"remove\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"key\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"remove\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"keys\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"},{\"name\":\"type\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"outputs\":[]},{\"type\":\"event\",\"name\":\"Put\",\"anonymous\":false,\"inputs\":[{\"name\":\"key\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"label\",\"type\":\"string\",\"indexed\":true,\"internalType\":\"string\"},{\"name\":\"tags\",\"type\":\"string[]\",\"indexed\":true,\"internalType\":\"string[]\"},{\"name\":\"price\",\"type\":\"uint128\",\"indexed\":false,\"internalType\":\"uint128\"}]},{\"type\":\"event\",\"name\":\"Removed\",\"anonymous\":false,\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"indexed\":false,\"internalType\":\"bytes32\"}]}]"
+
+// StoreItem is the Go value of the (address,uint128,string[]) tuple.
+type StoreItem struct {
+	Owner common.Address
+	Price *big.Int
+	Tags  []string
+}
+
+// Store is a typed binding of the Store contract.
+type Store struct {
+	Contract *accounts.Contract
+	backend  client.Backend
+}
+
+// NewStore binds the Store contract deployed at address.
+func NewStore(address common.Address, backend client.Backend) (*Store, error) {
+	parsed, err := abi.JSON(strings.NewReader(StoreABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Contract: accounts.NewContract(backend, address, &parsed), backend: backend}, nil
+}
+
+// At returns a binding whose calls are answered at the given revision.
+func (c *Store) At(revision client.Revision) *Store {
+	return &Store{Contract: c.Contract.At(revision), backend: c.backend}
+}
+
+// Get calls the get(bytes32) method.
+func (c *Store) Get(ctx context.Context, key [32]byte) (StoreItem, error) {
+	var out interface{}
+	if err := c.Contract.CallWithContext(ctx, "get", &out, key); err != nil {
+		return *new(StoreItem), err
+	}
+	return *abi.ConvertType(out, new(StoreItem)).(*StoreItem), nil
+}
+
+// Ping calls the ping() method.
+func (c *Store) Ping(ctx context.Context) error {
+	var out []interface{}
+	return c.Contract.CallWithContext(ctx, "ping", &out)
+}
+
+// StoreStatsOutput holds the outputs of the stats() method.
+type StoreStatsOutput struct {
+	Count   uint64
+	Arg1    *big.Int
+	Updated bool
+}
+
+// Stats calls the stats() method.
+func (c *Store) Stats(ctx context.Context) (StoreStatsOutput, error) {
+	var result StoreStatsOutput
+	out := make([]interface{}, 3)
+	if err := c.Contract.CallWithContext(ctx, "stats", &out); err != nil {
+		return result, err
+	}
+	result.Count = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	result.Arg1 = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	result.Updated = *abi.ConvertType(out[2], new(bool)).(*bool)
+	return result, nil
+}
+
+// Put sends a transaction calling the put(bytes32,(address,uint128,string[]),uint256) method.
+func (c *Store) Put(ctx context.Context, manager accounts.TxManager, value *big.Int, key [32]byte, item StoreItem, valueArg *big.Int) (*transactions.Visitor, error) {
+	clause, err := c.PutClause(value, key, item, valueArg)
+	if err != nil {
+		return nil, err
+	}
+	var txID common.Hash
+	if m, ok := manager.(accounts.ContextTxManager); ok {
+		txID, err = m.SendClausesWithContext(ctx, []*tx.Clause{clause})
+	} else {
+		txID, err = manager.SendClauses([]*tx.Clause{clause})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return transactions.New(c.backend, txID), nil
+}
+
+// PutClause returns a clause calling the put(bytes32,(address,uint128,string[]),uint256) method.
+func (c *Store) PutClause(value *big.Int, key [32]byte, item StoreItem, valueArg *big.Int) (*tx.Clause, error) {
+	clause, err := c.Contract.AsClause("put", key, item, valueArg)
+	if err != nil {
+		return nil, err
+	}
+	return clause.WithValue(value), nil
+}
+
+// Remove sends a transaction calling the remove(bytes32) method.
+func (c *Store) Remove(ctx context.Context, manager accounts.TxManager, key [32]byte) (*transactions.Visitor, error) {
+	return c.Contract.SendWithContext(ctx, manager, "remove", key)
+}
+
+// RemoveClause returns a clause calling the remove(bytes32) method.
+func (c *Store) RemoveClause(key [32]byte) (*tx.Clause, error) {
+	return c.Contract.AsClause("remove", key)
+}
+
+// Remove0 sends a transaction calling the remove(bytes32[],uint8) method.
+func (c *Store) Remove0(ctx context.Context, manager accounts.TxManager, keys [][32]byte, typeArg uint8) (*transactions.Visitor, error) {
+	return c.Contract.SendWithContext(ctx, manager, "remove0", keys, typeArg)
+}
+
+// Remove0Clause returns a clause calling the remove(bytes32[],uint8) method.
+func (c *Store) Remove0Clause(keys [][32]byte, typeArg uint8) (*tx.Clause, error) {
+	return c.Contract.AsClause("remove0", keys, typeArg)
+}
+
+// StorePut is a log of the Put(bytes32,string,string[],uint128) event.
+type StorePut struct {
+	Key   [32]byte
+	Label common.Hash
+	Tags  common.Hash
+	Price *big.Int
+	Raw   client.EventLog
+}
+
+// PutCriteria returns the criteria matching the Put logs of the contract. Each list holds the
+// accepted values of an indexed input, and a nil or empty list accepts any value.
+func (c *Store) PutCriteria(key [][32]byte, label []string) ([]client.EventCriteria, error) {
+	return c.Contract.Criteria().Event("Put", accounts.AnyOf(key...), accounts.AnyOf(label...), nil, nil).Build()
+}
+
+// DecodePut decodes a log of the Put(bytes32,string,string[],uint128) event.
+func (c *Store) DecodePut(log client.EventLog) (*StorePut, error) {
+	event := new(StorePut)
+	if err := c.Contract.UnpackEvent(event, "Put", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StoreRemoved is a log of the Removed(bytes32) event.
+type StoreRemoved struct {
+	Arg0 [32]byte
+	Raw  client.EventLog
+}
+
+// RemovedCriteria returns the criteria matching the Removed logs of the contract. Each list holds the
+// accepted values of an indexed input, and a nil or empty list accepts any value.
+func (c *Store) RemovedCriteria() ([]client.EventCriteria, error) {
+	return c.Contract.Criteria().Event("Removed", nil).Build()
+}
+
+// DecodeRemoved decodes a log of the Removed(bytes32) event.
+func (c *Store) DecodeRemoved(log client.EventLog) (*StoreRemoved, error) {
+	event := new(StoreRemoved)
+	if err := c.Contract.UnpackEvent(event, "Removed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,64 @@
+package store_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/cmd/thorgen/internal/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Clauses(t *testing.T) {
+	address := common.HexToAddress("0x01")
+	s, err := store.NewStore(address, nil)
+	assert.NoError(t, err)
+
+	item := store.StoreItem{Owner: common.HexToAddress("0x0a"), Price: big.NewInt(5), Tags: []string{"a", "b"}}
+	clause, err := s.PutClause(big.NewInt(7), [32]byte{1}, item, big.NewInt(9))
+	assert.NoError(t, err)
+	assert.Equal(t, address, *clause.To())
+	assert.Equal(t, big.NewInt(7), clause.Value())
+
+	values, err := s.Contract.ABI.Methods["put"].Inputs.Unpack(clause.Data()[4:])
+	assert.NoError(t, err)
+	assert.Equal(t, item.Tags, values[1].(struct {
+		Owner common.Address `json:"owner"`
+		Price *big.Int       `json:"price"`
+		Tags  []string       `json:"tags"`
+	}).Tags)
+	assert.Equal(t, big.NewInt(9), values[2])
+
+	// overloads are numbered
+	clause, err = s.Remove0Clause([][32]byte{{1}, {2}}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Contract.ABI.Methods["remove0"].ID, clause.Data()[:4])
+}
+
+func TestStore_Events(t *testing.T) {
+	s, err := store.NewStore(common.HexToAddress("0x01"), nil)
+	assert.NoError(t, err)
+
+	criteria, err := s.PutCriteria(nil, []string{"x", "y"})
+	assert.NoError(t, err)
+	assert.Len(t, criteria, 2)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("y")), *criteria[1].Topic2)
+
+	event := s.Contract.ABI.Events["Put"]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(42))
+	assert.NoError(t, err)
+	label := crypto.Keccak256Hash([]byte("x"))
+	log := client.EventLog{
+		Topics: []common.Hash{event.ID, {1}, label, {3}},
+		Data:   hexutil.Encode(data),
+	}
+	put, err := s.DecodePut(log)
+	assert.NoError(t, err)
+	assert.Equal(t, [32]byte{1}, put.Key)
+	assert.Equal(t, label, put.Label)
+	assert.Equal(t, big.NewInt(42), put.Price)
+	assert.Equal(t, log, put.Raw)
+}
@@ -0,0 +1,107 @@
+// Command thorgen generates type-safe Go bindings of a contract, on top of accounts.Contract.
+//
+//	thorgen -abi Token.abi -bin Token.bin -pkg token -type Token -out token.go
+//
+// The ABI can also be read from a compilation artifact, such as those of Hardhat, holding the ABI and the
+// bytecode:
+//
+//	thorgen -abi artifacts/contracts/Token.sol/Token.json -pkg token
+//
+// The bindings have a method per function of the contract: calls for view and pure functions, transactions and
+// clause builders for the others. Each event gets a struct, a criteria builder and a decoder, and a deploy
+// function is generated when the bytecode is known.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	abiPath := flag.String("abi", "", "path of the contract ABI, or of a compilation artifact holding it (- for stdin)")
+	binPath := flag.String("bin", "", "path of the contract bytecode, to generate a deploy function (optional)")
+	pkg := flag.String("pkg", "", "package name of the generated file")
+	typeName := flag.String("type", "", "type name of the binding (defaults to the name of the ABI file)")
+	out := flag.String("out", "", "output file (defaults to stdout)")
+	flag.Parse()
+
+	if err := run(*abiPath, *binPath, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "thorgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath, binPath, pkg, typeName, out string) error {
+	if abiPath == "" {
+		return errors.New("-abi is required")
+	}
+	if pkg == "" {
+		return errors.New("-pkg is required")
+	}
+	if typeName == "" {
+		if abiPath == "-" {
+			return errors.New("-type is required when the ABI is read from stdin")
+		}
+		typeName = exported(strings.TrimSuffix(filepath.Base(abiPath), filepath.Ext(abiPath)))
+	}
+
+	var (
+		raw []byte
+		err error
+	)
+	if abiPath == "-" {
+		raw, err = io.ReadAll(os.Stdin)
+	} else {
+		raw, err = os.ReadFile(abiPath)
+	}
+	if err != nil {
+		return fmt.Errorf("read ABI: %w", err)
+	}
+	abiJSON, bin, err := parseArtifact(raw)
+	if err != nil {
+		return err
+	}
+	if binPath != "" {
+		raw, err := os.ReadFile(binPath)
+		if err != nil {
+			return fmt.Errorf("read bytecode: %w", err)
+		}
+		bin = strings.TrimSpace(string(raw))
+	}
+
+	code, err := bind(pkg, typeName, abiJSON, bin)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(out, code, 0o644)
+}
+
+// parseArtifact returns the ABI and the bytecode of a compilation artifact. A plain ABI is returned as is.
+func parseArtifact(raw []byte) ([]byte, string, error) {
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		// an ABI is an array, so it can't be unmarshalled into the artifact
+		var entries []json.RawMessage
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, "", fmt.Errorf("parse ABI: %w", err)
+		}
+		return raw, "", nil
+	}
+	if len(artifact.ABI) == 0 {
+		return nil, "", errors.New("parse ABI: the artifact has no abi field")
+	}
+	return artifact.ABI, artifact.Bytecode, nil
+}
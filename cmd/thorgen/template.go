@@ -0,0 +1,172 @@
+package main
+
+const bindingSource = `// Code generated by thorgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/transactions"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = accounts.NewContract
+	_ = client.EventLog{}
+	_ = tx.NewClause
+	_ = transactions.New
+	_ = abi.ConvertType
+	_ = common.Big1
+)
+
+// {{.Type}}ABI is the ABI of the {{.Type}} contract.
+const {{.Type}}ABI = {{printf "%q" .ABI}}
+{{if .Bin}}
+// {{.Type}}Bin is the bytecode deploying the {{.Type}} contract.
+const {{.Type}}Bin = "{{.Bin}}"
+{{end}}
+{{- range .Structs}}
+// {{.Name}} is the Go value of the {{.Sig}} tuple.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+{{end}}
+// {{.Type}} is a typed binding of the {{.Type}} contract.
+type {{.Type}} struct {
+	Contract *accounts.Contract
+	backend  client.Backend
+}
+
+// New{{.Type}} binds the {{.Type}} contract deployed at address.
+func New{{.Type}}(address common.Address, backend client.Backend) (*{{.Type}}, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{Contract: accounts.NewContract(backend, address, &parsed), backend: backend}, nil
+}
+{{if .Bin}}
+// Deploy{{.Type}} deploys a new {{.Type}} contract and waits for its receipt.
+func Deploy{{.Type}}(ctx context.Context, backend client.Backend, sender accounts.TxManager{{lead (txParams .Constructor)}}) (*{{.Type}}, common.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	deployer := accounts.NewDeployer(backend, common.FromHex({{.Type}}Bin), &parsed){{if .Constructor.Payable}}.WithValue(value){{end}}
+	contract, txID, err := deployer.DeployWithContext(ctx, sender{{lead (args .Constructor.Inputs)}})
+	if err != nil {
+		return nil, txID, err
+	}
+	return &{{.Type}}{Contract: contract, backend: backend}, txID, nil
+}
+{{end}}
+// At returns a binding whose calls are answered at the given revision.
+func (c *{{.Type}}) At(revision client.Revision) *{{.Type}} {
+	return &{{.Type}}{Contract: c.Contract.At(revision), backend: c.backend}
+}
+{{range .Calls}}{{if gt (len .Outputs) 1}}
+// {{.Result}} holds the outputs of the {{.Sig}} method.
+type {{.Result}} struct {
+{{- range .Outputs}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+{{end}}
+// {{.Name}} calls the {{.Sig}} method.
+func (c *{{$.Type}}) {{.Name}}(ctx context.Context{{lead (params .Inputs)}}) {{if .Result}}({{.Result}}, error){{else}}error{{end}} {
+{{- if eq (len .Outputs) 0}}
+	var out []interface{}
+	return c.Contract.CallWithContext(ctx, "{{.Original}}", &out{{lead (args .Inputs)}})
+{{- else if eq (len .Outputs) 1}}
+	var out interface{}
+	if err := c.Contract.CallWithContext(ctx, "{{.Original}}", &out{{lead (args .Inputs)}}); err != nil {
+		return *new({{.Result}}), err
+	}
+	return *abi.ConvertType(out, new({{.Result}})).(*{{.Result}}), nil
+{{- else}}
+	var result {{.Result}}
+	out := make([]interface{}, {{len .Outputs}})
+	if err := c.Contract.CallWithContext(ctx, "{{.Original}}", &out{{lead (args .Inputs)}}); err != nil {
+		return result, err
+	}
+{{- range $i, $output := .Outputs}}
+	result.{{.Field}} = *abi.ConvertType(out[{{$i}}], new({{.Type}})).(*{{.Type}})
+{{- end}}
+	return result, nil
+{{- end}}
+}
+{{end}}
+{{- range .Transacts}}
+// {{.Name}} sends a transaction calling the {{.Sig}} method.
+func (c *{{$.Type}}) {{.Name}}(ctx context.Context, manager accounts.TxManager{{lead (txParams .)}}) (*transactions.Visitor, error) {
+{{- if .Payable}}
+	clause, err := c.{{.Name}}Clause(value{{lead (args .Inputs)}})
+	if err != nil {
+		return nil, err
+	}
+	var txID common.Hash
+	if m, ok := manager.(accounts.ContextTxManager); ok {
+		txID, err = m.SendClausesWithContext(ctx, []*tx.Clause{clause})
+	} else {
+		txID, err = manager.SendClauses([]*tx.Clause{clause})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return transactions.New(c.backend, txID), nil
+{{- else}}
+	return c.Contract.SendWithContext(ctx, manager, "{{.Original}}"{{lead (args .Inputs)}})
+{{- end}}
+}
+
+// {{.Name}}Clause returns a clause calling the {{.Sig}} method.
+func (c *{{$.Type}}) {{.Name}}Clause({{txParams .}}) (*tx.Clause, error) {
+{{- if .Payable}}
+	clause, err := c.Contract.AsClause("{{.Original}}"{{lead (args .Inputs)}})
+	if err != nil {
+		return nil, err
+	}
+	return clause.WithValue(value), nil
+{{- else}}
+	return c.Contract.AsClause("{{.Original}}"{{lead (args .Inputs)}})
+{{- end}}
+}
+{{end}}
+{{- range .Events}}
+// {{$.Type}}{{.Name}} is a log of the {{.Sig}} event.
+type {{$.Type}}{{.Name}} struct {
+{{- range .Inputs}}
+	{{.Field}} {{.Type}}
+{{- end}}
+	Raw client.EventLog
+}
+
+// {{.Name}}Criteria returns the criteria matching the {{.Original}} logs of the contract. Each list holds the
+// accepted values of an indexed input, and a nil or empty list accepts any value.
+func (c *{{$.Type}}) {{.Name}}Criteria({{matchers .Inputs}}) ([]client.EventCriteria, error) {
+	return c.Contract.Criteria().Event("{{.Original}}"{{range .Inputs}}, {{if .Matcher}}accounts.AnyOf({{.Name}}...){{else}}nil{{end}}{{end}}).Build()
+}
+
+// Decode{{.Name}} decodes a log of the {{.Sig}} event.
+func (c *{{$.Type}}) Decode{{.Name}}(log client.EventLog) (*{{$.Type}}{{.Name}}, error) {
+	event := new({{$.Type}}{{.Name}})
+	if err := c.Contract.UnpackEvent(event, "{{.Original}}", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+{{end}}`
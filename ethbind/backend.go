@@ -0,0 +1,408 @@
+// Package ethbind runs the contract bindings generated by abigen, the binding generator of go-ethereum, on
+// VechainThor. Backend implements bind.ContractBackend and bind.DeployBackend on top of a client.Backend, so the
+// generated bindings can be used unchanged:
+//
+//	backend := ethbind.New(c, signer, ethbind.Options{})
+//	token, err := erc20.NewToken(address, backend)
+//	balance, err := token.BalanceOf(nil, owner)
+//	tx, err := token.Transfer(backend.TransactOpts(ctx), to, amount)
+//	receipt, err := bind.WaitMined(ctx, backend, tx)
+//
+// Calls are answered by inspecting clauses, logs are filtered with the event filter of the node, and each
+// transaction of a binding is sent as a Thor transaction with a single clause, signed by the transactions.Signer.
+//
+// The semantics differ from Ethereum in a few places:
+//   - The Ethereum transactions built by the bindings are never sent, so they don't need to be signed, and their
+//     nonce, gas price and signature are ignored. TransactOpts returns options which skip signing.
+//   - The hash of an Ethereum transaction isn't the ID of the Thor transaction which was sent. The Backend maps the
+//     hashes of the last transactions it sent to their IDs, so TransactionReceipt, bind.WaitMined and
+//     bind.WaitDeployed accept either.
+//   - Thor derives the address of a contract from the ID of the transaction deploying it, so the address returned
+//     by the deploy functions of the bindings is wrong. Use the address returned by bind.WaitDeployed instead.
+//   - Headers have no base fee, so the bindings build legacy transactions, and blocks are identified by their Thor
+//     ID, which isn't the hash of the header.
+package ethbind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/transactions"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+var (
+	// ErrNoSigner is returned when a Backend created without a signer is asked to send a transaction.
+	ErrNoSigner = errors.New("ethbind: the backend has no signer")
+	// ErrQueryExceedsLimit is returned when a log query spans more blocks or matches more logs than the limits of
+	// the Options.
+	ErrQueryExceedsLimit = errors.New("ethbind: query exceeds limit")
+)
+
+// Options configures a Backend.
+type Options struct {
+	// GasPriceCoef is the gas price coefficient of the transactions sent. Defaults to 0.
+	GasPriceCoef uint8
+	// PollInterval is how often SubscribeFilterLogs queries new logs. Defaults to 1 second.
+	PollInterval time.Duration
+	// Transactions is the number of transactions sent whose hashes are mapped to their IDs. Defaults to 4096.
+	Transactions int
+	// MaxBlockRange is the maximum number of blocks of a log query. Defaults to 100 000, a negative value disables
+	// the limit.
+	MaxBlockRange int64
+	// MaxLogs is the maximum number of logs returned by a log query. Defaults to 10 000, a negative value disables
+	// the limit.
+	MaxLogs int
+}
+
+// Backend implements bind.ContractBackend and bind.DeployBackend on top of a client.Backend. It is safe for
+// concurrent use.
+type Backend struct {
+	client client.Backend
+	signer transactions.Signer
+	opts   Options
+
+	nonce atomic.Uint64
+
+	// ids maps the hashes of the Ethereum transactions sent to the IDs of the Thor transactions, and hashes the
+	// other way around.
+	ids    *lru.Cache[common.Hash, common.Hash]
+	hashes *lru.Cache[common.Hash, common.Hash]
+}
+
+var (
+	_ bind.ContractBackend = (*Backend)(nil)
+	_ bind.DeployBackend   = (*Backend)(nil)
+)
+
+// New creates a Backend which sends transactions signed by signer. The signer can be nil for bindings which only
+// call contracts and filter logs.
+func New(c client.Backend, signer transactions.Signer, opts Options) *Backend {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.Transactions <= 0 {
+		opts.Transactions = 4096
+	}
+	if opts.MaxBlockRange == 0 {
+		opts.MaxBlockRange = 100_000
+	}
+	if opts.MaxLogs == 0 {
+		opts.MaxLogs = 10_000
+	}
+	return &Backend{
+		client: c,
+		signer: signer,
+		opts:   opts,
+		ids:    lru.NewCache[common.Hash, common.Hash](opts.Transactions),
+		hashes: lru.NewCache[common.Hash, common.Hash](opts.Transactions),
+	}
+}
+
+// TransactOpts returns the options to transact with the bindings as the signer of the backend. The transactions
+// built by the bindings aren't signed, since the backend signs the Thor transactions instead.
+func (b *Backend) TransactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := &bind.TransactOpts{
+		Context: ctx,
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+	if b.signer != nil {
+		opts.From = b.signer.Address()
+	}
+	return opts
+}
+
+// CodeAt returns the code of an account at a block number, or at the best block if number is nil.
+func (b *Backend) CodeAt(ctx context.Context, account common.Address, number *big.Int) ([]byte, error) {
+	revision, err := revisionOf(number)
+	if err != nil {
+		return nil, err
+	}
+	code, err := b.client.AccountCodeAtWithContext(ctx, account, revision)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(code.Code)
+}
+
+// PendingCodeAt returns the code of an account in the block being packed.
+func (b *Backend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	code, err := b.client.AccountCodeAtWithContext(ctx, account, client.RevisionNext)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(code.Code)
+}
+
+// CallContract inspects the call at a block number, or at the best block if number is nil. A reverted call
+// returns an error whose ErrorData method returns the revert data, as an RPC client does.
+func (b *Backend) CallContract(ctx context.Context, call ethereum.CallMsg, number *big.Int) ([]byte, error) {
+	revision, err := revisionOf(number)
+	if err != nil {
+		return nil, err
+	}
+	return b.CallContractAt(ctx, call, revision)
+}
+
+// CallContractAt is like CallContract, but inspects the call at a revision, which can also be a block ID or a
+// named revision such as client.RevisionFinalized.
+func (b *Backend) CallContractAt(ctx context.Context, call ethereum.CallMsg, revision client.Revision) ([]byte, error) {
+	request := inspectRequest(call)
+	if call.Gas != 0 {
+		request.Gas = &call.Gas
+	}
+	response, err := b.client.InspectAtWithContext(ctx, request, revision)
+	if err != nil {
+		return nil, err
+	}
+	return result(response[0])
+}
+
+// EstimateGas returns the gas of a Thor transaction holding the call as its single clause: the intrinsic gas of
+// the clause and the gas used executing it.
+func (b *Backend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	request := inspectRequest(call)
+	simulation, err := transactions.NewTransactor(b.client, request.Clauses).SimulateWithContext(ctx, call.From)
+	if err != nil {
+		return 0, err
+	}
+	if simulation.Reverted() {
+		if _, err := result(simulation.Outputs()[0]); err != nil {
+			return 0, err
+		}
+		return 0, vm.ErrExecutionReverted
+	}
+	return simulation.TotalGas(), nil
+}
+
+// SuggestGasPrice returns 0. The gas price of the Ethereum transactions is ignored, the Thor transactions are
+// priced by Options.GasPriceCoef.
+func (b *Backend) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+// SuggestGasTipCap returns 0, see SuggestGasPrice.
+func (b *Backend) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+// HeaderByNumber returns a header holding the fields of a block which have an Ethereum equivalent. The header has
+// no base fee, so the bindings build legacy transactions.
+func (b *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	revision, err := revisionOf(number)
+	if err != nil {
+		return nil, err
+	}
+	block, err := b.client.BlockWithContext(ctx, revision)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &types.Header{
+		ParentHash:  block.ParentID,
+		Coinbase:    block.Beneficiary,
+		Root:        block.StateRoot,
+		TxHash:      block.TxsRoot,
+		ReceiptHash: block.ReceiptsRoot,
+		Difficulty:  new(big.Int),
+		Number:      big.NewInt(block.Number),
+		GasLimit:    uint64(block.GasLimit),
+		GasUsed:     uint64(block.GasUsed),
+		Time:        uint64(block.Timestamp),
+	}, nil
+}
+
+// PendingNonceAt returns a counter of the backend, which only keeps the hashes of the Ethereum transactions
+// unique. Thor transactions have random nonces.
+func (b *Backend) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return b.nonce.Add(1), nil
+}
+
+// SendTransaction sends a Thor transaction with the recipient, value, data and gas of the Ethereum transaction as
+// its single clause, signed by the signer of the backend.
+func (b *Backend) SendTransaction(ctx context.Context, ethTx *types.Transaction) error {
+	if b.signer == nil {
+		return ErrNoSigner
+	}
+	clause := tx.NewClause(ethTx.To()).WithValue(ethTx.Value()).WithData(ethTx.Data())
+	transactor := transactions.NewTransactor(b.client, []*tx.Clause{clause}).GasPriceCoef(b.opts.GasPriceCoef)
+	if ethTx.Gas() != 0 {
+		transactor.Gas(ethTx.Gas())
+	}
+	visitor, err := transactor.SendWithContext(ctx, b.signer)
+	if err != nil {
+		return err
+	}
+
+	b.ids.Add(ethTx.Hash(), visitor.ID())
+	b.hashes.Add(visitor.ID(), ethTx.Hash())
+	return nil
+}
+
+// TransactionID returns the ID of the Thor transaction sent for an Ethereum transaction, or the hash itself if
+// the backend didn't send it or no longer maps it.
+func (b *Backend) TransactionID(hash common.Hash) common.Hash {
+	if id, ok := b.ids.Get(hash); ok {
+		return id
+	}
+	return hash
+}
+
+// transactionHash is the reverse of TransactionID.
+func (b *Backend) transactionHash(id common.Hash) common.Hash {
+	if hash, ok := b.hashes.Get(id); ok {
+		return hash
+	}
+	return id
+}
+
+// TransactionReceipt returns the receipt of a transaction, by the hash of the Ethereum transaction sent by the
+// backend or by the ID of the Thor transaction. ethereum.NotFound is returned until the transaction is packed.
+func (b *Backend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	id := b.TransactionID(hash)
+	receipt, err := b.client.TransactionReceiptWithContext(ctx, id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+
+	converted := &types.Receipt{
+		Type:              types.LegacyTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: uint64(receipt.GasUsed),
+		GasUsed:           uint64(receipt.GasUsed),
+		TxHash:            b.transactionHash(id),
+		BlockHash:         receipt.Meta.BlockID,
+		BlockNumber:       big.NewInt(receipt.Meta.BlockNumber),
+	}
+	if receipt.Reverted {
+		converted.Status = types.ReceiptStatusFailed
+	}
+	for _, output := range receipt.Outputs {
+		if output.ContractAddress != "" && converted.ContractAddress == (common.Address{}) {
+			converted.ContractAddress = common.HexToAddress(output.ContractAddress)
+		}
+		for _, event := range output.Events {
+			data, err := hexutil.Decode(event.Data)
+			if err != nil {
+				return nil, fmt.Errorf("ethbind: decode log data: %w", err)
+			}
+			converted.Logs = append(converted.Logs, &types.Log{
+				Address:     event.Address,
+				Topics:      event.Topics,
+				Data:        data,
+				BlockNumber: uint64(receipt.Meta.BlockNumber),
+				TxHash:      converted.TxHash,
+				BlockHash:   receipt.Meta.BlockID,
+				Index:       uint(len(converted.Logs)),
+			})
+		}
+	}
+	converted.Bloom = types.CreateBloom(types.Receipts{converted})
+	return converted, nil
+}
+
+// revisionOf returns the revision of a block number, the best block if it is nil or negative, as are the named
+// blocks of go-ethereum such as rpc.LatestBlockNumber.
+func revisionOf(number *big.Int) (client.Revision, error) {
+	if number == nil || number.Sign() < 0 {
+		return client.RevisionBest, nil
+	}
+	if !number.IsUint64() || number.Uint64() > uint64(^uint32(0)) {
+		return client.Revision{}, fmt.Errorf("ethbind: block number %s out of range", number)
+	}
+	return client.RevisionNumber(uint32(number.Uint64())), nil
+}
+
+func inspectRequest(call ethereum.CallMsg) client.InspectRequest {
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	request := client.InspectRequest{
+		Clauses: []*tx.Clause{tx.NewClause(call.To).WithValue(value).WithData(call.Data)},
+	}
+	if call.From != (common.Address{}) {
+		request.Caller = &call.From
+	}
+	return request
+}
+
+// result returns the output data of an inspection, or a revertError.
+func result(response client.InspectResponse) ([]byte, error) {
+	data, err := hexutil.Decode(response.Data)
+	if err != nil {
+		return nil, fmt.Errorf("ethbind: decode output: %w", err)
+	}
+	switch {
+	case response.VmError != "" && response.VmError != vm.ErrExecutionReverted.Error():
+		return nil, errors.New(response.VmError)
+	case response.Reverted || response.VmError != "":
+		return nil, &revertError{data: data}
+	}
+	return data, nil
+}
+
+// revertError is the error of a reverted call. Like the errors of go-ethereum's RPC client, it implements
+// rpc.DataError, so the revert data can be decoded with abi.UnpackRevert.
+type revertError struct {
+	data []byte
+}
+
+func (e *revertError) Error() string {
+	if reason, err := abi.UnpackRevert(e.data); err == nil {
+		return vm.ErrExecutionReverted.Error() + ": " + reason
+	}
+	return vm.ErrExecutionReverted.Error()
+}
+
+// ErrorCode returns the code of reverted calls in the JSON-RPC API of go-ethereum.
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the revert data in hex.
+func (e *revertError) ErrorData() interface{} {
+	return hexutil.Encode(e.data)
+}
+
+// limitError is the error of a log query exceeding a limit of the Backend. It wraps ErrQueryExceedsLimit.
+type limitError struct {
+	reason string
+}
+
+func (e *limitError) Error() string {
+	return ErrQueryExceedsLimit.Error() + ": " + e.reason
+}
+
+func (e *limitError) Unwrap() error {
+	return ErrQueryExceedsLimit
+}
+
+// ErrorCode returns the "limit exceeded" code of EIP-1474.
+func (e *limitError) ErrorCode() int {
+	return -32005
+}
+
+func notFound(err error) error {
+	if errors.Is(err, client.ErrNotFound) {
+		return ethereum.NotFound
+	}
+	return err
+}
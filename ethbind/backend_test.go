@@ -0,0 +1,207 @@
+package ethbind_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo"
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/ethbind"
+	"github.com/darrenvechain/thorgo/simulated"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBackend(t *testing.T) (*ethbind.Backend, *txmanager.PKManager) {
+	backend, err := simulated.NewBackend(nil, simulated.WithAutoMine())
+	assert.NoError(t, err)
+	signer := txmanager.FromPK(solo.Keys()[0], thorgo.FromClient(backend))
+	return ethbind.New(backend, signer, ethbind.Options{PollInterval: 10 * time.Millisecond}), signer
+}
+
+func TestBackend_BoundContract(t *testing.T) {
+	ctx := context.Background()
+	backend, signer := newBackend(t)
+	vtho := bind.NewBoundContract(builtins.VTHO.Address, *builtins.VTHO.ABI, backend, backend, backend)
+	recipient := common.HexToAddress("0x1234")
+
+	balanceOf := func() *big.Int {
+		var out []interface{}
+		assert.NoError(t, vtho.Call(&bind.CallOpts{Context: ctx}, &out, "balanceOf", recipient))
+		return out[0].(*big.Int)
+	}
+	assert.Zero(t, balanceOf().Sign())
+
+	ethTx, err := vtho.Transact(backend.TransactOpts(ctx), "transfer", recipient, big.NewInt(100))
+	assert.NoError(t, err)
+	receipt, err := bind.WaitMined(ctx, backend, ethTx)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, ethTx.Hash(), receipt.TxHash)
+	assert.NotEqual(t, ethTx.Hash(), backend.TransactionID(ethTx.Hash()))
+	assert.Len(t, receipt.Logs, 1)
+	assert.Equal(t, big.NewInt(100), balanceOf())
+
+	// the receipt can be found by the Thor ID as well
+	byID, err := backend.TransactionReceipt(ctx, backend.TransactionID(ethTx.Hash()))
+	assert.NoError(t, err)
+	assert.Equal(t, receipt.TxHash, byID.TxHash)
+	_, err = backend.TransactionReceipt(ctx, common.Hash{1})
+	assert.ErrorIs(t, err, ethereum.NotFound)
+
+	logs, sub, err := vtho.FilterLogs(&bind.FilterOpts{Context: ctx}, "Transfer", []interface{}{signer.Address()},
+		[]interface{}{recipient})
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+	select {
+	case log := <-logs:
+		assert.Equal(t, ethTx.Hash(), log.TxHash)
+		assert.Equal(t, builtins.VTHO.Address, log.Address)
+		assert.Equal(t, receipt.BlockNumber.Uint64(), log.BlockNumber)
+	case <-time.After(time.Second):
+		t.Fatal("no log")
+	}
+}
+
+func TestBackend_Revert(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newBackend(t)
+	vtho := bind.NewBoundContract(builtins.VTHO.Address, *builtins.VTHO.ABI, backend, backend, backend)
+	tooMuch := new(big.Int).Lsh(big.NewInt(1), 200)
+
+	var out []interface{}
+	err := vtho.Call(&bind.CallOpts{Context: ctx, From: common.HexToAddress("0x1234")}, &out, "transfer",
+		common.HexToAddress("0x5678"), tooMuch)
+	assert.ErrorContains(t, err, "execution reverted")
+	_, isDataError := err.(interface{ ErrorData() interface{} })
+	assert.True(t, isDataError)
+
+	_, err = vtho.Transact(backend.TransactOpts(ctx), "transfer", common.HexToAddress("0x5678"), tooMuch)
+	assert.ErrorContains(t, err, "execution reverted")
+}
+
+func TestBackend_Deploy(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newBackend(t)
+	// returns the runtime code 0x60006000f3
+	bytecode := hexutil.MustDecode("0x6460006000f3600052600560" + "1bf3")
+
+	address, ethTx, _, err := bind.DeployContract(backend.TransactOpts(ctx), abi.ABI{}, bytecode, backend)
+	assert.NoError(t, err)
+	deployed, err := bind.WaitDeployed(ctx, backend, ethTx)
+	assert.NoError(t, err)
+	assert.NotEqual(t, address, deployed, "the address computed by go-ethereum is the Ethereum one")
+
+	code, err := backend.CodeAt(ctx, deployed, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.MustDecode("0x60006000f3"), code)
+}
+
+func TestBackend_FilterLogsNamedBlocks(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newBackend(t)
+	vtho := bind.NewBoundContract(builtins.VTHO.Address, *builtins.VTHO.ABI, backend, backend, backend)
+	recipient := common.HexToAddress("0x1234")
+
+	first, err := vtho.Transact(backend.TransactOpts(ctx), "transfer", recipient, big.NewInt(1))
+	assert.NoError(t, err)
+	_, err = bind.WaitMined(ctx, backend, first)
+	assert.NoError(t, err)
+	second, err := vtho.Transact(backend.TransactOpts(ctx), "transfer", recipient, big.NewInt(2))
+	assert.NoError(t, err)
+	_, err = bind.WaitMined(ctx, backend, second)
+	assert.NoError(t, err)
+
+	query := ethereum.FilterQuery{Addresses: []common.Address{builtins.VTHO.Address}}
+	all, err := backend.FilterLogs(ctx, query)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// a named FromBlock is the best block, not the genesis
+	query.FromBlock = big.NewInt(int64(rpc.LatestBlockNumber))
+	latest, err := backend.FilterLogs(ctx, query)
+	assert.NoError(t, err)
+	assert.Len(t, latest, 1)
+	assert.Equal(t, second.Hash(), latest[0].TxHash)
+
+	query.FromBlock, query.ToBlock = new(big.Int), big.NewInt(int64(rpc.LatestBlockNumber))
+	all, err = backend.FilterLogs(ctx, query)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestBackend_FilterLogsLimit(t *testing.T) {
+	sim, err := simulated.NewBackend(nil)
+	assert.NoError(t, err)
+	_, err = sim.Commit()
+	assert.NoError(t, err)
+	backend := ethbind.New(sim, nil, ethbind.Options{MaxBlockRange: 1})
+
+	_, err = backend.FilterLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(0)})
+	assert.ErrorIs(t, err, ethbind.ErrQueryExceedsLimit)
+	_, err = backend.FilterLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(1)})
+	assert.NoError(t, err)
+}
+
+func TestBackend_SubscribeFilterLogs(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newBackend(t)
+	vtho := bind.NewBoundContract(builtins.VTHO.Address, *builtins.VTHO.ABI, backend, backend, backend)
+	recipient := common.HexToAddress("0x1234")
+
+	logs, sub, err := vtho.WatchLogs(&bind.WatchOpts{Context: ctx}, "Transfer", nil, []interface{}{recipient})
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	ethTx, err := vtho.Transact(backend.TransactOpts(ctx), "transfer", recipient, big.NewInt(1))
+	assert.NoError(t, err)
+	select {
+	case log := <-logs:
+		assert.Equal(t, ethTx.Hash(), log.TxHash)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no log")
+	}
+}
+
+func TestBackend_TransactionsLimit(t *testing.T) {
+	ctx := context.Background()
+	sim, err := simulated.NewBackend(nil, simulated.WithAutoMine())
+	assert.NoError(t, err)
+	signer := txmanager.FromPK(solo.Keys()[0], thorgo.FromClient(sim))
+	backend := ethbind.New(sim, signer, ethbind.Options{Transactions: 1})
+	vtho := bind.NewBoundContract(builtins.VTHO.Address, *builtins.VTHO.ABI, backend, backend, backend)
+
+	first, err := vtho.Transact(backend.TransactOpts(ctx), "transfer", common.HexToAddress("0x1234"), big.NewInt(1))
+	assert.NoError(t, err)
+	second, err := vtho.Transact(backend.TransactOpts(ctx), "transfer", common.HexToAddress("0x1234"), big.NewInt(1))
+	assert.NoError(t, err)
+
+	// only the hash of the last transaction is kept
+	assert.Equal(t, first.Hash(), backend.TransactionID(first.Hash()))
+	assert.NotEqual(t, second.Hash(), backend.TransactionID(second.Hash()))
+}
+
+func TestBackend_NoSigner(t *testing.T) {
+	ctx := context.Background()
+	sim, err := simulated.NewBackend(nil)
+	assert.NoError(t, err)
+	backend := ethbind.New(sim, nil, ethbind.Options{})
+	vtho := bind.NewBoundContract(builtins.VTHO.Address, *builtins.VTHO.ABI, backend, backend, backend)
+
+	opts := backend.TransactOpts(ctx)
+	opts.GasLimit = 100_000
+	_, err = vtho.Transact(opts, "transfer", common.HexToAddress("0x1234"), big.NewInt(1))
+	assert.ErrorIs(t, err, ethbind.ErrNoSigner)
+}
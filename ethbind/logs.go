@@ -0,0 +1,240 @@
+package ethbind
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/darrenvechain/thorgo/accounts"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/events"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// FilterLogs returns the logs matching a query, in ascending order. Each address and each combination of the
+// accepted topics of the query is a criteria of the Thor event filter, and the criteria set is limited to
+// accounts.DefaultCriteriaLimit criteria. A query spanning more than Options.MaxBlockRange blocks, or matching
+// more than Options.MaxLogs logs, fails with ErrQueryExceedsLimit.
+func (b *Backend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	criteria, err := criteriaOf(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to int64
+	if query.BlockHash != nil {
+		block, err := b.client.BlockWithContext(ctx, client.RevisionID(*query.BlockHash))
+		if err != nil {
+			return nil, notFound(err)
+		}
+		from, to = block.Number, block.Number
+	} else {
+		best, err := b.client.BestBlockWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		from, to = 0, best.Number
+		if query.FromBlock != nil {
+			if from, err = blockNumberOf(query.FromBlock, best.Number); err != nil {
+				return nil, err
+			}
+		}
+		if query.ToBlock != nil {
+			if to, err = blockNumberOf(query.ToBlock, best.Number); err != nil {
+				return nil, err
+			}
+			to = min(to, best.Number)
+		}
+		if from > to {
+			return nil, nil
+		}
+		if b.opts.MaxBlockRange > 0 && to-from+1 > b.opts.MaxBlockRange {
+			return nil, &limitError{reason: fmt.Sprintf("the range spans %d blocks, more than %d", to-from+1,
+				b.opts.MaxBlockRange)}
+		}
+	}
+
+	it := events.New(b.client, criteria).BlockRange(from, to).IncludeIndexes().Iterator(ctx, events.IteratorOptions{})
+	defer it.Close()
+	converted := make([]types.Log, 0)
+	for it.Next() {
+		for _, log := range it.Page() {
+			if query.BlockHash != nil && log.Meta.BlockID != *query.BlockHash {
+				continue
+			}
+			if b.opts.MaxLogs > 0 && len(converted) == b.opts.MaxLogs {
+				return nil, &limitError{reason: fmt.Sprintf("the query returned more than %d results", b.opts.MaxLogs)}
+			}
+			l, err := b.convertLog(log)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, l)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+// SubscribeFilterLogs delivers the logs matching a query as blocks are packed, by querying the logs of the new
+// blocks every Options.PollInterval. The logs of blocks orphaned by a reorganisation aren't retracted. FromBlock
+// and ToBlock are ignored, the subscription starts after the best block.
+func (b *Backend) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	if _, err := criteriaOf(query); err != nil {
+		return nil, err
+	}
+	best, err := b.client.BestBlockWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	next := best.Number + 1
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+			case <-ctx.Done():
+			}
+			cancel()
+		}()
+
+		timer := time.NewTimer(b.opts.PollInterval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-timer.C:
+			}
+
+			best, err := b.client.BestBlockWithContext(ctx)
+			if err != nil {
+				return err
+			}
+			if best.Number >= next {
+				q := query
+				q.BlockHash = nil
+				q.FromBlock, q.ToBlock = big.NewInt(next), big.NewInt(best.Number)
+				logs, err := b.FilterLogs(ctx, q)
+				if err != nil {
+					return err
+				}
+				for _, log := range logs {
+					select {
+					case ch <- log:
+					case <-quit:
+						return nil
+					}
+				}
+				next = best.Number + 1
+			}
+			timer.Reset(b.opts.PollInterval)
+		}
+	}), nil
+}
+
+// blockNumberOf returns the number of a block of a query. Negative numbers are the named blocks of go-ethereum,
+// which are the best block as for revisionOf.
+func blockNumberOf(number *big.Int, best int64) (int64, error) {
+	if number.Sign() < 0 {
+		return best, nil
+	}
+	if !number.IsInt64() {
+		return 0, fmt.Errorf("ethbind: block number %s out of range", number)
+	}
+	return number.Int64(), nil
+}
+
+func (b *Backend) convertLog(log client.EventLog) (types.Log, error) {
+	data, err := hexutil.Decode(log.Data)
+	if err != nil {
+		return types.Log{}, fmt.Errorf("ethbind: decode log data: %w", err)
+	}
+	converted := types.Log{
+		Topics:      log.Topics,
+		Data:        data,
+		BlockNumber: uint64(log.Meta.BlockNumber),
+		TxHash:      b.transactionHash(log.Meta.TxID),
+		BlockHash:   log.Meta.BlockID,
+	}
+	if log.Address != nil {
+		converted.Address = *log.Address
+	}
+	if log.Meta.TxIndex != nil {
+		converted.TxIndex = uint(*log.Meta.TxIndex)
+	}
+	if log.Meta.LogIndex != nil {
+		converted.Index = uint(*log.Meta.LogIndex)
+	}
+	return converted, nil
+}
+
+// criteriaOf expands the addresses and the topics of a query into a criteria set.
+func criteriaOf(query ethereum.FilterQuery) ([]client.EventCriteria, error) {
+	if len(query.Topics) > 5 {
+		return nil, fmt.Errorf("ethbind: %d topics, Thor logs have at most 5", len(query.Topics))
+	}
+	// the expansion is the product of the lists, so the size is checked before expanding
+	count := max(len(query.Addresses), 1)
+	for _, topics := range query.Topics {
+		count *= max(len(topics), 1)
+		if count > accounts.DefaultCriteriaLimit {
+			break
+		}
+	}
+	if count > accounts.DefaultCriteriaLimit {
+		return nil, fmt.Errorf("%w: the query exceeds the limit of %d", accounts.ErrTooManyCriteria,
+			accounts.DefaultCriteriaLimit)
+	}
+
+	set := []client.EventCriteria{{}}
+	if len(query.Addresses) > 0 {
+		set = make([]client.EventCriteria, len(query.Addresses))
+		for i := range query.Addresses {
+			set[i].Address = &query.Addresses[i]
+		}
+	}
+	for position, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		expanded := make([]client.EventCriteria, 0, len(set)*len(topics))
+		for _, criteria := range set {
+			for i := range topics {
+				c := criteria
+				*topicOf(&c, position) = &topics[i]
+				expanded = append(expanded, c)
+			}
+		}
+		set = expanded
+	}
+	return set, nil
+}
+
+func topicOf(criteria *client.EventCriteria, position int) **common.Hash {
+	switch position {
+	case 0:
+		return &criteria.Topic0
+	case 1:
+		return &criteria.Topic1
+	case 2:
+		return &criteria.Topic2
+	case 3:
+		return &criteria.Topic3
+	default:
+		return &criteria.Topic4
+	}
+}
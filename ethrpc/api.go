@@ -0,0 +1,252 @@
+package ethrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/ethbind"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ethAPI holds the methods of the eth namespace. The calls, estimations and log queries go through an
+// ethbind.Backend without a signer.
+type ethAPI struct {
+	client  client.Backend
+	backend *ethbind.Backend
+}
+
+func newEthAPI(c client.Backend, opts Options) *ethAPI {
+	backend := ethbind.New(c, nil, ethbind.Options{MaxBlockRange: opts.MaxBlockRange, MaxLogs: opts.MaxLogs})
+	return &ethAPI{client: c, backend: backend}
+}
+
+// ChainId returns the chain tag.
+func (api *ethAPI) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(api.client.ChainTag())
+}
+
+// BlockNumber returns the number of the best block.
+func (api *ethAPI) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	best, err := api.client.BestBlockWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(best.Number), nil
+}
+
+// GetBalance returns the VET balance of an account at a block.
+func (api *ethAPI) GetBalance(
+	ctx context.Context,
+	address common.Address,
+	block rpc.BlockNumberOrHash,
+) (*hexutil.Big, error) {
+	revision, err := revisionOf(block)
+	if err != nil {
+		return nil, err
+	}
+	account, err := api.client.AccountAtWithContext(ctx, address, revision)
+	if err != nil {
+		return nil, err
+	}
+	return &account.Balance, nil
+}
+
+// Call inspects a call at a block, the best block by default. A reverted call returns an error with the revert
+// data, as go-ethereum does.
+func (api *ethAPI) Call(ctx context.Context, args callArgs, block *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	revision := client.RevisionBest
+	if block != nil {
+		var err error
+		if revision, err = revisionOf(*block); err != nil {
+			return nil, err
+		}
+	}
+	return api.backend.CallContractAt(ctx, args.callMsg(), revision)
+}
+
+// EstimateGas returns the gas of a Thor transaction holding the call as its single clause. The call is simulated
+// at the best block, the block requested is ignored.
+func (api *ethAPI) EstimateGas(ctx context.Context, args callArgs, _ *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	gas, err := api.backend.EstimateGas(ctx, args.callMsg())
+	return hexutil.Uint64(gas), err
+}
+
+// GetLogs returns the logs matching a filter. The block range defaults to the best block.
+func (api *ethAPI) GetLogs(ctx context.Context, args filterArgs) ([]types.Log, error) {
+	query := ethereum.FilterQuery{BlockHash: args.BlockHash, Addresses: args.Addresses, Topics: args.Topics}
+	if args.BlockHash == nil {
+		var err error
+		if query.FromBlock, err = api.blockNumber(ctx, args.FromBlock); err != nil {
+			return nil, err
+		}
+		if query.ToBlock, err = api.blockNumber(ctx, args.ToBlock); err != nil {
+			return nil, err
+		}
+	}
+	logs, err := api.backend.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, nil
+}
+
+// GetTransactionReceipt returns the receipt of a transaction by its ID, or null until it is packed.
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, id common.Hash) (map[string]interface{}, error) {
+	receipt, err := api.client.TransactionReceiptWithContext(ctx, id)
+	if errors.Is(err, client.ErrNotFound) || (err == nil && receipt == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := api.client.TransactionWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.client.BlockWithContext(ctx, client.RevisionID(receipt.Meta.BlockID))
+	if err != nil {
+		return nil, err
+	}
+	index := 0
+	for i, txID := range block.Transactions {
+		if txID == id {
+			index = i
+			break
+		}
+	}
+	return marshalReceipt(receipt, transaction, index)
+}
+
+// SendRawTransaction sends an RLP encoded Thor transaction, and returns its ID.
+func (api *ethAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	transaction, err := tx.Decode(input)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("ethrpc: not a Thor transaction: %w", err)
+	}
+	response, err := api.client.SendTransactionWithContext(ctx, transaction)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return response.ID, nil
+}
+
+// blockNumber returns the number of a block of a log query, resolving the named blocks. The pending block is the
+// best block, since it has no logs yet.
+func (api *ethAPI) blockNumber(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
+	if number == nil || *number == rpc.PendingBlockNumber {
+		latest := rpc.LatestBlockNumber
+		number = &latest
+	}
+	if *number >= 0 {
+		return big.NewInt(number.Int64()), nil
+	}
+	revision, err := revisionOf(rpc.BlockNumberOrHashWithNumber(*number))
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.client.BlockWithContext(ctx, revision)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(block.Number), nil
+}
+
+// revisionOf returns the revision of a block, named, by number or by ID.
+func revisionOf(block rpc.BlockNumberOrHash) (client.Revision, error) {
+	if hash, ok := block.Hash(); ok {
+		return client.RevisionID(hash), nil
+	}
+	number, _ := block.Number()
+	switch number {
+	case rpc.LatestBlockNumber:
+		return client.RevisionBest, nil
+	case rpc.PendingBlockNumber:
+		return client.RevisionNext, nil
+	case rpc.SafeBlockNumber:
+		return client.RevisionJustified, nil
+	case rpc.FinalizedBlockNumber:
+		return client.RevisionFinalized, nil
+	case rpc.EarliestBlockNumber:
+		return client.RevisionNumber(0), nil
+	}
+	if number < 0 || number > math.MaxUint32 {
+		return client.Revision{}, fmt.Errorf("ethrpc: block number %d out of range", number)
+	}
+	return client.RevisionNumber(uint32(number)), nil
+}
+
+// marshalReceipt returns the JSON fields of the receipt of a transaction, the transaction at the given index of
+// its block.
+func marshalReceipt(
+	receipt *client.TransactionReceipt,
+	transaction *client.Transaction,
+	index int,
+) (map[string]interface{}, error) {
+	status := types.ReceiptStatusSuccessful
+	if receipt.Reverted {
+		status = types.ReceiptStatusFailed
+	}
+	gasPrice := new(big.Int)
+	if receipt.Paid != nil && receipt.GasUsed > 0 {
+		gasPrice.Div(receipt.Paid.ToInt(), big.NewInt(receipt.GasUsed))
+	}
+	var to *common.Address
+	if len(transaction.Clauses) > 0 {
+		to = transaction.Clauses[0].To()
+	}
+
+	var contractAddress *common.Address
+	logs := make([]*types.Log, 0)
+	for _, output := range receipt.Outputs {
+		if output.ContractAddress != "" && contractAddress == nil {
+			address := common.HexToAddress(output.ContractAddress)
+			contractAddress = &address
+		}
+		for _, event := range output.Events {
+			data, err := hexutil.Decode(event.Data)
+			if err != nil {
+				return nil, fmt.Errorf("ethrpc: decode log data: %w", err)
+			}
+			logs = append(logs, &types.Log{
+				Address:     event.Address,
+				Topics:      event.Topics,
+				Data:        data,
+				BlockNumber: uint64(receipt.Meta.BlockNumber),
+				TxHash:      receipt.Meta.TxID,
+				TxIndex:     uint(index),
+				BlockHash:   receipt.Meta.BlockID,
+				Index:       uint(len(logs)),
+			})
+		}
+	}
+	bloom := types.CreateBloom(types.Receipts{{Logs: logs}})
+
+	return map[string]interface{}{
+		"type":              hexutil.Uint(types.LegacyTxType),
+		"status":            hexutil.Uint64(status),
+		"cumulativeGasUsed": hexutil.Uint64(receipt.GasUsed),
+		"gasUsed":           hexutil.Uint64(receipt.GasUsed),
+		"effectiveGasPrice": (*hexutil.Big)(gasPrice),
+		"logsBloom":         bloom,
+		"logs":              logs,
+		"transactionHash":   receipt.Meta.TxID,
+		"transactionIndex":  hexutil.Uint64(index),
+		"blockHash":         receipt.Meta.BlockID,
+		"blockNumber":       hexutil.Uint64(receipt.Meta.BlockNumber),
+		"from":              receipt.Meta.TxOrigin,
+		"to":                to,
+		"contractAddress":   contractAddress,
+	}, nil
+}
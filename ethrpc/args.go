@@ -0,0 +1,112 @@
+package ethrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// callArgs are the arguments of eth_call and eth_estimateGas. The fee fields are ignored.
+type callArgs struct {
+	From  *common.Address `json:"from"`
+	To    *common.Address `json:"to"`
+	Gas   *hexutil.Uint64 `json:"gas"`
+	Value *hexutil.Big    `json:"value"`
+	Data  *hexutil.Bytes  `json:"data"`
+	// Input is the newer name of Data, and takes precedence over it.
+	Input *hexutil.Bytes `json:"input"`
+}
+
+func (args callArgs) callMsg() ethereum.CallMsg {
+	msg := ethereum.CallMsg{To: args.To}
+	if args.From != nil {
+		msg.From = *args.From
+	}
+	if args.Gas != nil {
+		msg.Gas = uint64(*args.Gas)
+	}
+	if args.Value != nil {
+		msg.Value = args.Value.ToInt()
+	}
+	switch {
+	case args.Input != nil:
+		msg.Data = *args.Input
+	case args.Data != nil:
+		msg.Data = *args.Data
+	}
+	return msg
+}
+
+// filterArgs are the arguments of eth_getLogs.
+type filterArgs struct {
+	BlockHash *common.Hash
+	FromBlock *rpc.BlockNumber
+	ToBlock   *rpc.BlockNumber
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// UnmarshalJSON decodes a filter, whose address is an address or a list of addresses, and whose topics are each
+// null, a topic or a list of topics.
+func (args *filterArgs) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *common.Hash      `json:"blockHash"`
+		FromBlock *rpc.BlockNumber  `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber  `json:"toBlock"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.BlockHash != nil && (raw.FromBlock != nil || raw.ToBlock != nil) {
+		return errors.New("ethrpc: blockHash can't be combined with fromBlock or toBlock")
+	}
+	args.BlockHash, args.FromBlock, args.ToBlock = raw.BlockHash, raw.FromBlock, raw.ToBlock
+
+	args.Addresses = nil
+	if err := unmarshalOneOrMany(raw.Address, &args.Addresses); err != nil {
+		return fmt.Errorf("ethrpc: invalid address: %w", err)
+	}
+	args.Topics = make([][]common.Hash, len(raw.Topics))
+	for i, topic := range raw.Topics {
+		var topics []*common.Hash
+		if err := unmarshalOneOrMany(topic, &topics); err != nil {
+			return fmt.Errorf("ethrpc: invalid topic %d: %w", i, err)
+		}
+		for _, t := range topics {
+			// a null among the alternatives matches any topic
+			if t == nil {
+				topics = nil
+				break
+			}
+		}
+		for _, t := range topics {
+			args.Topics[i] = append(args.Topics[i], *t)
+		}
+	}
+	return nil
+}
+
+// unmarshalOneOrMany decodes a list, or a single value as a list of one value. Null is an empty list.
+func unmarshalOneOrMany[T any](data json.RawMessage, list *[]T) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if data[0] == '[' {
+		return json.Unmarshal(data, list)
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*list = append(*list, value)
+	return nil
+}
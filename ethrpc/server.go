@@ -0,0 +1,68 @@
+// Package ethrpc serves a subset of the Ethereum JSON-RPC API on top of a Thor node, for the tools which only speak
+// eth_* JSON-RPC, such as Foundry scripts, ethers based services and block explorers:
+//
+//	server, err := ethrpc.NewServer(c, ethrpc.Options{})
+//	http.ListenAndServe("localhost:8545", server)
+//
+// The methods served are eth_chainId, eth_blockNumber, eth_getBalance, eth_call, eth_estimateGas, eth_getLogs,
+// eth_getTransactionReceipt and eth_sendRawTransaction. They are translated to the REST API of the node, and their
+// semantics differ from Ethereum in a few places:
+//   - eth_chainId returns the chain tag, the last byte of the ID of the genesis block.
+//   - Blocks are identified by their Thor ID, which isn't the hash of their header. The "safe" and "finalized"
+//     blocks are the justified and finalized blocks of Thor, and "pending" is the block being packed for
+//     eth_getBalance and eth_call, and the best block otherwise.
+//   - eth_getBalance returns the VET balance of the account. Its VTHO balance is the balance of the VTHO contract.
+//   - eth_estimateGas returns the gas of a Thor transaction holding the call as its single clause, estimated at
+//     the best block whatever the block requested.
+//   - eth_sendRawTransaction accepts RLP encoded Thor transactions, and returns their ID. Ethereum transactions
+//     can't be sent, since their signature doesn't cover the fields of a Thor transaction.
+//   - Transactions are identified by their Thor ID. The receipt of a transaction with many clauses holds the logs of
+//     all its clauses, its "to" is the recipient of the first clause and its contract address the first contract
+//     deployed. The effective gas price is the VTHO paid per unit of gas.
+//   - eth_getLogs is limited to accounts.DefaultCriteriaLimit combinations of addresses and topics, and to the
+//     block range and number of logs of the Options. A query exceeding them fails with the "limit exceeded" code
+//     of EIP-1474.
+package ethrpc
+
+import (
+	"net/http"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Options configures a Server.
+type Options struct {
+	// MaxBlockRange is the maximum number of blocks of an eth_getLogs query. Defaults to 100 000, a negative value
+	// disables the limit.
+	MaxBlockRange int64
+	// MaxLogs is the maximum number of logs returned by an eth_getLogs query. Defaults to 10 000, a negative value
+	// disables the limit.
+	MaxLogs int
+}
+
+// Server serves the Ethereum JSON-RPC API over HTTP. It is safe for concurrent use.
+type Server struct {
+	rpc *rpc.Server
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// NewServer creates a Server answering the requests with the node of the backend.
+func NewServer(c client.Backend, opts Options) (*Server, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", newEthAPI(c, opts)); err != nil {
+		return nil, err
+	}
+	return &Server{rpc: server}, nil
+}
+
+// ServeHTTP serves a JSON-RPC request, or a batch of requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.rpc.ServeHTTP(w, r)
+}
+
+// Stop stops serving new requests, and cancels the requests in progress.
+func (s *Server) Stop() {
+	s.rpc.Stop()
+}
@@ -0,0 +1,206 @@
+package ethrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/darrenvechain/thorgo"
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/ethrpc"
+	"github.com/darrenvechain/thorgo/simulated"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/transactions"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, opts ethrpc.Options) (*simulated.Backend, *rpc.Client, *ethclient.Client) {
+	backend, err := simulated.NewBackend(nil, simulated.WithAutoMine())
+	assert.NoError(t, err)
+	server, err := ethrpc.NewServer(backend, opts)
+	assert.NoError(t, err)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	rpcClient, err := rpc.Dial(httpServer.URL)
+	assert.NoError(t, err)
+	return backend, rpcClient, ethclient.NewClient(rpcClient)
+}
+
+func TestServer_Chain(t *testing.T) {
+	ctx := context.Background()
+	backend, _, eth := newServer(t, ethrpc.Options{})
+
+	chainID, err := eth.ChainID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(backend.ChainTag()), chainID.Int64())
+
+	number, err := eth.BlockNumber(ctx)
+	assert.NoError(t, err)
+	best, err := backend.BestBlockWithContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(best.Number), number)
+
+	owner := solo.Keys()[0]
+	balance, err := eth.BalanceAt(ctx, txmanager.FromPK(owner, nil).Address(), nil)
+	assert.NoError(t, err)
+	assert.Positive(t, balance.Sign())
+	genesis, err := eth.BalanceAt(ctx, txmanager.FromPK(owner, nil).Address(), big.NewInt(0))
+	assert.NoError(t, err)
+	assert.Equal(t, balance, genesis)
+}
+
+func TestServer_Call(t *testing.T) {
+	ctx := context.Background()
+	_, _, eth := newServer(t, ethrpc.Options{})
+	owner := txmanager.FromPK(solo.Keys()[0], nil).Address()
+
+	data, err := builtins.VTHO.ABI.Pack("balanceOf", owner)
+	assert.NoError(t, err)
+	output, err := eth.CallContract(ctx, ethereum.CallMsg{To: &builtins.VTHO.Address, Data: data}, nil)
+	assert.NoError(t, err)
+	assert.Positive(t, new(big.Int).SetBytes(output).Sign())
+
+	tooMuch := new(big.Int).Lsh(big.NewInt(1), 200)
+	data, err = builtins.VTHO.ABI.Pack("transfer", common.HexToAddress("0x1234"), tooMuch)
+	assert.NoError(t, err)
+	_, err = eth.CallContract(ctx, ethereum.CallMsg{From: owner, To: &builtins.VTHO.Address, Data: data}, nil)
+	assert.ErrorContains(t, err, "execution reverted")
+	var dataError rpc.DataError
+	assert.ErrorAs(t, err, &dataError)
+
+	data, err = builtins.VTHO.ABI.Pack("transfer", common.HexToAddress("0x1234"), big.NewInt(1))
+	assert.NoError(t, err)
+	gas, err := eth.EstimateGas(ctx, ethereum.CallMsg{From: owner, To: &builtins.VTHO.Address, Data: data})
+	assert.NoError(t, err)
+	assert.Greater(t, gas, uint64(21_000))
+}
+
+func TestServer_Transaction(t *testing.T) {
+	ctx := context.Background()
+	backend, rpcClient, eth := newServer(t, ethrpc.Options{})
+	signer := txmanager.FromPK(solo.Keys()[0], thorgo.FromClient(backend))
+	recipient := common.HexToAddress("0x1234")
+
+	data, err := builtins.VTHO.ABI.Pack("transfer", recipient, big.NewInt(100))
+	assert.NoError(t, err)
+	clause := tx.NewClause(&builtins.VTHO.Address).WithData(data)
+	transaction, err := transactions.NewTransactor(backend, []*tx.Clause{clause}).
+		BuildWithContext(ctx, signer.Address())
+	assert.NoError(t, err)
+	signature, err := signer.SignTransaction(transaction)
+	assert.NoError(t, err)
+	encoded, err := transaction.WithSignature(signature).Encoded()
+	assert.NoError(t, err)
+
+	var id common.Hash
+	assert.NoError(t, rpcClient.CallContext(ctx, &id, "eth_sendRawTransaction", "0x"+encoded))
+	assert.Equal(t, transaction.WithSignature(signature).ID(), id)
+
+	receipt, err := eth.TransactionReceipt(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, id, receipt.TxHash)
+	assert.Len(t, receipt.Logs, 1)
+	assert.True(t, types.BloomLookup(receipt.Bloom, builtins.VTHO.Address))
+
+	var fields map[string]interface{}
+	assert.NoError(t, rpcClient.CallContext(ctx, &fields, "eth_getTransactionReceipt", id))
+	assert.Equal(t, signer.Address(), common.HexToAddress(fields["from"].(string)))
+	assert.Equal(t, builtins.VTHO.Address, common.HexToAddress(fields["to"].(string)))
+
+	_, err = eth.TransactionReceipt(ctx, common.Hash{1})
+	assert.ErrorIs(t, err, ethereum.NotFound)
+
+	logs, err := eth.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
+		Addresses: []common.Address{builtins.VTHO.Address},
+		Topics:    [][]common.Hash{{builtins.VTHO.ABI.Events["Transfer"].ID}, nil, {common.BytesToHash(recipient[:])}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, id, logs[0].TxHash)
+
+	logs, err = eth.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &receipt.BlockHash})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+}
+
+func TestServer_SendEthereumTransaction(t *testing.T) {
+	ctx := context.Background()
+	_, rpcClient, _ := newServer(t, ethrpc.Options{})
+
+	ethTx, err := types.NewTx(&types.LegacyTx{Gas: 21_000, To: &common.Address{}}).MarshalBinary()
+	assert.NoError(t, err)
+	var id common.Hash
+	err = rpcClient.CallContext(ctx, &id, "eth_sendRawTransaction", hexutil.Encode(ethTx))
+	assert.ErrorContains(t, err, "not a Thor transaction")
+}
+
+func TestServer_GetLogsArgs(t *testing.T) {
+	ctx := context.Background()
+	_, rpcClient, _ := newServer(t, ethrpc.Options{})
+
+	var logs []json.RawMessage
+	err := rpcClient.CallContext(ctx, &logs, "eth_getLogs", map[string]interface{}{
+		"fromBlock": "earliest",
+		"toBlock":   "latest",
+		"address":   builtins.VTHO.Address,
+		"topics":    []interface{}{nil, []interface{}{nil, common.Hash{1}}},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, logs)
+
+	err = rpcClient.CallContext(ctx, &logs, "eth_getLogs", map[string]interface{}{
+		"blockHash": common.Hash{1},
+		"fromBlock": "0x0",
+	})
+	assert.ErrorContains(t, err, "blockHash can't be combined")
+}
+
+func TestServer_GetLogsLimits(t *testing.T) {
+	ctx := context.Background()
+	backend, rpcClient, eth := newServer(t, ethrpc.Options{MaxBlockRange: 3, MaxLogs: 1})
+	signer := txmanager.FromPK(solo.Keys()[0], thorgo.FromClient(backend))
+
+	// a single transaction transferring VTHO twice
+	data, err := builtins.VTHO.ABI.Pack("transfer", common.HexToAddress("0x1234"), big.NewInt(1))
+	assert.NoError(t, err)
+	clause := tx.NewClause(&builtins.VTHO.Address).WithData(data)
+	visitor, err := transactions.NewTransactor(backend, []*tx.Clause{clause, clause}).Send(signer)
+	assert.NoError(t, err)
+	receipt, err := visitor.Wait()
+	assert.NoError(t, err)
+	for range 3 {
+		_, err = backend.Commit()
+		assert.NoError(t, err)
+	}
+
+	var limitErr rpc.Error
+	_, err = eth.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(0)})
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, -32005, limitErr.ErrorCode())
+	assert.ErrorContains(t, err, "more than 3")
+
+	_, err = eth.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &receipt.Meta.BlockID})
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, -32005, limitErr.ErrorCode())
+	assert.ErrorContains(t, err, "more than 1 results")
+
+	var logs []json.RawMessage
+	assert.NoError(t, rpcClient.CallContext(ctx, &logs, "eth_getLogs", map[string]interface{}{"fromBlock": "latest"}))
+	assert.Empty(t, logs)
+}
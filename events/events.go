@@ -1,6 +1,8 @@
 package events
 
 import (
+	"context"
+
 	"github.com/darrenvechain/thorgo/client"
 )
 
@@ -12,11 +14,12 @@ var (
 )
 
 type Filter struct {
-	client  *client.Client
-	request *client.EventFilter
+	client         client.Backend
+	request        *client.EventFilter
+	includeIndexes *bool
 }
 
-func New(c *client.Client, criteria []client.EventCriteria) *Filter {
+func New(c client.Backend, criteria []client.EventCriteria) *Filter {
 	return &Filter{client: c, request: &client.EventFilter{
 		Criteria: &criteria,
 	}}
@@ -34,6 +37,14 @@ func (f *Filter) Asc() *Filter {
 	return f
 }
 
+// IncludeIndexes asks the node to set the transaction and log indexes in the metadata of the events.
+// Nodes which don't support it leave them nil.
+func (f *Filter) IncludeIndexes() *Filter {
+	include := true
+	f.includeIndexes = &include
+	return f
+}
+
 // BlockRange sets the range of blocks to filter events.
 func (f *Filter) BlockRange(from int64, to int64) *Filter {
 	f.request.Range = &client.FilterRange{
@@ -56,10 +67,16 @@ func (f *Filter) TimeRange(from int64, to int64) *Filter {
 
 // Apply executes the filter and returns the events.
 func (f *Filter) Apply(offset int64, limit int64) ([]client.EventLog, error) {
+	return f.ApplyWithContext(context.Background(), offset, limit)
+}
+
+// ApplyWithContext is like Apply but uses the given context for the request.
+func (f *Filter) ApplyWithContext(ctx context.Context, offset int64, limit int64) ([]client.EventLog, error) {
 	f.request.Options = &client.FilterOptions{
-		Offset: &offset,
-		Limit:  &limit,
+		Offset:         &offset,
+		Limit:          &limit,
+		IncludeIndexes: f.includeIndexes,
 	}
 
-	return f.client.FilterEvents(f.request)
+	return f.client.FilterEventsWithContext(ctx, f.request)
 }
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/internal/paging"
+)
+
+// IteratorOptions configures Filter.Iterator. The zero value of a field selects its default.
+type IteratorOptions = paging.Options
+
+// Iterator delivers the events matching a filter, page by page.
+type Iterator = paging.Iterator[client.EventLog]
+
+// Iterator pages through every event matching the filter, so the caller doesn't have to deal with offset and
+// limit. A block range is bounded by the best block and split into chunks which are queried concurrently, while
+// the pages are delivered in the order of the filter. Other ranges are paged through sequentially.
+//
+// The iteration stops when ctx is done. Close must be called if the iteration is abandoned early.
+func (f *Filter) Iterator(ctx context.Context, opts IteratorOptions) *Iterator {
+	request := *f.request
+	includeIndexes := f.includeIndexes
+	desc := request.Order != nil && *request.Order == descending
+	fetch := func(ctx context.Context, rng *client.FilterRange, offset, limit int64) ([]client.EventLog, error) {
+		page := request
+		page.Range = rng
+		page.Options = &client.FilterOptions{Offset: &offset, Limit: &limit, IncludeIndexes: includeIndexes}
+		return f.client.FilterEventsWithContext(ctx, &page)
+	}
+	return paging.New(ctx, request.Range, desc, opts, paging.BestBlock(f.client), fetch)
+}
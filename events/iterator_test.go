@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/thortest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIterator drains the events of a block range in pages and chunks
+func TestIterator(t *testing.T) {
+	node := thortest.NewNode(t)
+	node.MineEmpty(30)
+	addr := common.HexToAddress("0x1234")
+	for number := int64(1); number <= 30; number++ {
+		node.AddEventLogs(
+			client.EventLog{Address: &addr, Meta: client.LogMeta{BlockNumber: number}},
+			client.EventLog{Address: &addr, Meta: client.LogMeta{BlockNumber: number, ClauseIndex: 1}},
+		)
+	}
+	c := node.Client()
+	opts := IteratorOptions{PageSize: 3, ChunkSize: 4, Concurrency: 2}
+
+	logs, err := New(c, []client.EventCriteria{{Address: &addr}}).
+		BlockRange(5, 1000).
+		Iterator(context.Background(), opts).
+		All()
+	assert.NoError(t, err)
+	assert.Len(t, logs, 52)
+	assert.Equal(t, int64(5), logs[0].Meta.BlockNumber)
+	assert.Equal(t, int64(30), logs[51].Meta.BlockNumber)
+
+	it := New(c, []client.EventCriteria{{Address: &addr}}).BlockRange(1, 30).Desc().Iterator(context.Background(), opts)
+	defer it.Close()
+	assert.True(t, it.Next())
+	assert.Len(t, it.Page(), 3)
+	assert.Equal(t, int64(30), it.Page()[0].Meta.BlockNumber)
+	assert.Equal(t, int64(1), it.Page()[0].Meta.ClauseIndex)
+	assert.Equal(t, int64(29), it.Page()[2].Meta.BlockNumber)
+}
@@ -0,0 +1,125 @@
+// Package logfilter applies the event and transfer log filters of the Thor REST API to logs held in memory.
+package logfilter
+
+import (
+	"math"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Events returns the logs matching the filter. logs must be in chain order.
+func Events(logs []client.EventLog, filter *client.EventFilter) []client.EventLog {
+	return apply(logs, filter.Range, filter.Options, filter.Order, func(l *client.EventLog) bool {
+		if filter.Criteria == nil || len(*filter.Criteria) == 0 {
+			return true
+		}
+		for _, c := range *filter.Criteria {
+			if MatchEvent(c, l) {
+				return true
+			}
+		}
+		return false
+	}, func(l *client.EventLog) *client.LogMeta {
+		return &l.Meta
+	})
+}
+
+// Transfers returns the logs matching the filter. logs must be in chain order.
+func Transfers(logs []client.TransferLog, filter *client.TransferFilter) []client.TransferLog {
+	return apply(logs, filter.Range, filter.Options, filter.Order, func(l *client.TransferLog) bool {
+		if filter.Criteria == nil || len(*filter.Criteria) == 0 {
+			return true
+		}
+		for _, c := range *filter.Criteria {
+			if MatchTransfer(c, l) {
+				return true
+			}
+		}
+		return false
+	}, func(l *client.TransferLog) *client.LogMeta {
+		return &l.Meta
+	})
+}
+
+// MatchEvent reports whether the log matches every field set in the criteria.
+func MatchEvent(c client.EventCriteria, l *client.EventLog) bool {
+	if c.Address != nil && (l.Address == nil || *l.Address != *c.Address) {
+		return false
+	}
+	for i, topic := range []*common.Hash{c.Topic0, c.Topic1, c.Topic2, c.Topic3, c.Topic4} {
+		if topic != nil && (i >= len(l.Topics) || l.Topics[i] != *topic) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchTransfer reports whether the log matches every field set in the criteria.
+func MatchTransfer(c client.TransferCriteria, l *client.TransferLog) bool {
+	if c.TxOrigin != nil && l.Meta.TxOrigin != *c.TxOrigin {
+		return false
+	}
+	if c.Sender != nil && l.Sender != *c.Sender {
+		return false
+	}
+	return c.Recipient == nil || l.Recipient == *c.Recipient
+}
+
+// apply applies the range, criteria, order and pagination of a log filter. The indexes of the logs are removed
+// unless they are requested.
+func apply[T any](
+	logs []T,
+	rng *client.FilterRange,
+	options *client.FilterOptions,
+	order *string,
+	match func(*T) bool,
+	meta func(*T) *client.LogMeta,
+) []T {
+	from, to := int64(0), int64(math.MaxInt64)
+	byTime := false
+	if rng != nil {
+		if rng.From != nil {
+			from = *rng.From
+		}
+		if rng.To != nil {
+			to = *rng.To
+		}
+		byTime = rng.Unit != nil && *rng.Unit == "time"
+	}
+
+	matched := make([]T, 0)
+	for i := range logs {
+		m := meta(&logs[i])
+		position := m.BlockNumber
+		if byTime {
+			position = m.BlockTime
+		}
+		if position < from || position > to || !match(&logs[i]) {
+			continue
+		}
+		matched = append(matched, logs[i])
+	}
+
+	if order != nil && *order == "desc" {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+
+	if options != nil {
+		if options.Offset != nil {
+			matched = matched[min(int(*options.Offset), len(matched)):]
+		}
+		if options.Limit != nil {
+			matched = matched[:min(int(*options.Limit), len(matched))]
+		}
+	}
+	if options == nil || options.IncludeIndexes == nil || !*options.IncludeIndexes {
+		for i := range matched {
+			m := meta(&matched[i])
+			m.TxIndex, m.LogIndex = nil, nil
+		}
+	}
+	return matched
+}
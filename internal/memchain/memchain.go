@@ -0,0 +1,77 @@
+// Package memchain holds the chain bookkeeping shared by the in-memory backends of the simulated and thortest
+// packages.
+package memchain
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlockID derives a block ID which, as on Thor, starts with the block number.
+func BlockID(parent common.Hash, number int64) common.Hash {
+	var num [4]byte
+	binary.BigEndian.PutUint32(num[:], uint32(number))
+	id := crypto.Keccak256Hash(parent.Bytes(), num[:])
+	copy(id[:4], num[:])
+	return id
+}
+
+// Index appends the events and transfers of a receipt to the logs indexed so far, in chain order, and returns the
+// updated logs. txIndex is the position of the transaction in its block. The logs of a reverted transaction aren't
+// indexed.
+func Index(
+	events []client.EventLog,
+	transfers []client.TransferLog,
+	receipt *client.TransactionReceipt,
+	txIndex int64,
+) ([]client.EventLog, []client.TransferLog) {
+	if receipt.Reverted {
+		return events, transfers
+	}
+	for i, output := range receipt.Outputs {
+		meta := client.LogMeta{
+			BlockID:     receipt.Meta.BlockID,
+			BlockNumber: receipt.Meta.BlockNumber,
+			BlockTime:   receipt.Meta.BlockTimestamp,
+			TxID:        receipt.Meta.TxID,
+			TxOrigin:    receipt.Meta.TxOrigin,
+			ClauseIndex: int64(i),
+			TxIndex:     &txIndex,
+		}
+		for _, ev := range output.Events {
+			addr := ev.Address
+			logIndex := count(events, meta.BlockID, func(l *client.EventLog) *client.LogMeta { return &l.Meta })
+			meta.LogIndex = &logIndex
+			events = append(events, client.EventLog{Address: &addr, Topics: ev.Topics, Data: ev.Data, Meta: meta})
+		}
+		for _, tr := range output.Transfers {
+			logIndex := count(transfers, meta.BlockID, func(l *client.TransferLog) *client.LogMeta { return &l.Meta })
+			meta.LogIndex = &logIndex
+			amount := new(big.Int)
+			if tr.Amount != nil {
+				amount = tr.Amount.ToInt()
+			}
+			transfers = append(transfers, client.TransferLog{
+				Sender:    tr.Sender,
+				Recipient: tr.Recipient,
+				Amount:    hexutil.Big(*amount),
+				Meta:      meta,
+			})
+		}
+	}
+	return events, transfers
+}
+
+// count returns the number of logs of the block at the end of logs, which is the log index of the next one.
+func count[T any](logs []T, blockID common.Hash, meta func(*T) *client.LogMeta) int64 {
+	n := int64(0)
+	for i := len(logs) - 1; i >= 0 && meta(&logs[i]).BlockID == blockID; i-- {
+		n++
+	}
+	return n
+}
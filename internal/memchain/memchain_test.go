@@ -0,0 +1,47 @@
+package memchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockID(t *testing.T) {
+	id := BlockID(common.Hash{1}, 0x01020304)
+	assert.Equal(t, []byte{1, 2, 3, 4}, id[:4])
+	assert.NotEqual(t, id, BlockID(common.Hash{2}, 0x01020304))
+}
+
+func TestIndex(t *testing.T) {
+	receipt := &client.TransactionReceipt{
+		Meta: client.ReceiptMeta{BlockID: common.Hash{1}, TxOrigin: common.Address{2}},
+		Outputs: []client.Output{
+			{Events: []client.Event{{Address: common.Address{3}}, {Address: common.Address{4}}}},
+			// a transfer without amount is indexed with a zero amount
+			{Transfers: []client.Transfer{{Amount: (*hexutil.Big)(big.NewInt(5))}, {}}},
+		},
+	}
+	events, transfers := Index(nil, nil, receipt, 7)
+	assert.Len(t, events, 2)
+	assert.Len(t, transfers, 2)
+	assert.Equal(t, int64(1), *events[1].Meta.LogIndex)
+	assert.Equal(t, int64(7), *events[1].Meta.TxIndex)
+	assert.Equal(t, common.Address{2}, events[1].Meta.TxOrigin)
+	assert.Equal(t, int64(1), transfers[1].Meta.ClauseIndex)
+	assert.Equal(t, int64(1), *transfers[1].Meta.LogIndex)
+	assert.Zero(t, transfers[1].Amount.ToInt().Sign())
+
+	// the log index restarts in the next block
+	receipt.Meta.BlockID = common.Hash{2}
+	events, _ = Index(events, transfers, receipt, 0)
+	assert.Len(t, events, 4)
+	assert.Equal(t, int64(0), *events[2].Meta.LogIndex)
+
+	receipt.Reverted = true
+	events, _ = Index(events, transfers, receipt, 0)
+	assert.Len(t, events, 4)
+}
@@ -0,0 +1,232 @@
+// Package paging drains log queries page by page. It is shared by the events and transfers packages.
+//
+// A block range is split into chunks which are queried concurrently, while the pages are delivered in the order
+// of the query: the chunks are delivered one after the other, lowest blocks first, or highest blocks first for a
+// descending query.
+package paging
+
+import (
+	"context"
+
+	"github.com/darrenvechain/thorgo/client"
+)
+
+// Options configures an Iterator. The zero value of a field selects its default.
+type Options struct {
+	// PageSize is the limit of each request. Defaults to 256.
+	PageSize int64
+	// ChunkSize is the number of blocks of each chunk. Defaults to 100 000.
+	ChunkSize int64
+	// Concurrency is the number of chunks queried at the same time. Defaults to 4.
+	Concurrency int
+}
+
+// Fetch queries the logs of a range, in the order of the query.
+type Fetch[T any] func(ctx context.Context, rng *client.FilterRange, offset, limit int64) ([]T, error)
+
+// Best returns the number of the best block, which bounds the block ranges before they are split.
+type Best func(ctx context.Context) (int64, error)
+
+// BestBlock returns a Best reading the best block of c.
+func BestBlock(c client.Backend) Best {
+	return func(ctx context.Context) (int64, error) {
+		blk, err := c.BestBlockWithContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return blk.Number, nil
+	}
+}
+
+// Iterator delivers the pages of a query. The events and transfers packages expose it as their Iterator.
+type Iterator[T any] struct {
+	cancel context.CancelFunc
+	// chunks receives the chunks in the order of the query. It is closed once every chunk is started, or after
+	// runErr is set.
+	chunks  chan *chunk[T]
+	runErr  error
+	current *chunk[T]
+	page    []T
+	err     error
+}
+
+type chunk[T any] struct {
+	rng   *client.FilterRange
+	pages chan []T
+	// err is set before pages is closed.
+	err error
+}
+
+// New starts draining the logs of rng with fetch. Block ranges with both ends set are bounded by the best block
+// and split into chunks, other ranges are queried as a whole. desc must match the order of the query.
+func New[T any](
+	ctx context.Context,
+	rng *client.FilterRange,
+	desc bool,
+	opts Options,
+	best Best,
+	fetch Fetch[T],
+) *Iterator[T] {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 256
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 100_000
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	it := &Iterator[T]{cancel: cancel, chunks: make(chan *chunk[T], opts.Concurrency)}
+	go it.run(ctx, rng, desc, opts, best, fetch)
+	return it
+}
+
+// run starts a worker per chunk, at most opts.Concurrency at a time.
+func (it *Iterator[T]) run(
+	ctx context.Context,
+	rng *client.FilterRange,
+	desc bool,
+	opts Options,
+	best Best,
+	fetch Fetch[T],
+) {
+	defer close(it.chunks)
+
+	if isBlockRange(rng) {
+		number, err := best(ctx)
+		if err != nil {
+			it.runErr = err
+			return
+		}
+		if *rng.To > number {
+			to := number
+			rng = &client.FilterRange{Unit: rng.Unit, From: rng.From, To: &to}
+		}
+	}
+
+	sem := make(chan struct{}, opts.Concurrency)
+	for _, r := range split(rng, opts.ChunkSize, desc) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			it.runErr = ctx.Err()
+			return
+		}
+		// the buffer lets a chunk run ahead of the one being delivered
+		c := &chunk[T]{rng: r, pages: make(chan []T, 4)}
+		select {
+		case it.chunks <- c:
+		case <-ctx.Done():
+			it.runErr = ctx.Err()
+			return
+		}
+		go func() {
+			defer func() { <-sem }()
+			c.drain(ctx, opts.PageSize, fetch)
+		}()
+	}
+}
+
+// drain fetches the pages of the chunk until a page is shorter than the limit.
+func (c *chunk[T]) drain(ctx context.Context, limit int64, fetch Fetch[T]) {
+	defer close(c.pages)
+	for offset := int64(0); ; {
+		if err := ctx.Err(); err != nil {
+			c.err = err
+			return
+		}
+		page, err := fetch(ctx, c.rng, offset, limit)
+		if err != nil {
+			c.err = err
+			return
+		}
+		if len(page) > 0 {
+			select {
+			case c.pages <- page:
+			case <-ctx.Done():
+				c.err = ctx.Err()
+				return
+			}
+		}
+		if int64(len(page)) < limit {
+			return
+		}
+		offset += int64(len(page))
+	}
+}
+
+// Next advances to the next page. It returns false once every log has been delivered, or after an error.
+func (it *Iterator[T]) Next() bool {
+	for it.err == nil {
+		if it.current == nil {
+			c, ok := <-it.chunks
+			if !ok {
+				it.err = it.runErr
+				break
+			}
+			it.current = c
+		}
+		if page, ok := <-it.current.pages; ok {
+			it.page = page
+			return true
+		}
+		if it.current.err != nil {
+			it.err = it.current.err
+			break
+		}
+		it.current = nil
+	}
+	it.page = nil
+	it.cancel()
+	return false
+}
+
+// Page returns the current page.
+func (it *Iterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the queries in flight. It must be called when the iteration is abandoned before Next returns false.
+func (it *Iterator[T]) Close() {
+	it.cancel()
+}
+
+// All drains the iterator and returns every log.
+func (it *Iterator[T]) All() ([]T, error) {
+	all := make([]T, 0)
+	for it.Next() {
+		all = append(all, it.Page()...)
+	}
+	return all, it.Err()
+}
+
+func isBlockRange(rng *client.FilterRange) bool {
+	return rng != nil && rng.From != nil && rng.To != nil && (rng.Unit == nil || *rng.Unit == "block")
+}
+
+// split divides a block range into chunks of size blocks, in the order of the query. An inverted range, which
+// may start after the best block, has no chunks.
+func split(rng *client.FilterRange, size int64, desc bool) []*client.FilterRange {
+	if !isBlockRange(rng) {
+		return []*client.FilterRange{rng}
+	}
+
+	var chunks []*client.FilterRange
+	for from := *rng.From; from <= *rng.To; from += size {
+		start, end := from, min(from+size-1, *rng.To)
+		chunks = append(chunks, &client.FilterRange{Unit: rng.Unit, From: &start, To: &end})
+	}
+	if desc {
+		for i, j := 0, len(chunks)-1; i < j; i, j = i+1, j-1 {
+			chunks[i], chunks[j] = chunks[j], chunks[i]
+		}
+	}
+	return chunks
+}
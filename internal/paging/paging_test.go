@@ -0,0 +1,146 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// logs returns a fetch serving one log per block, holding the block number, in the given order.
+func logs(count int64, desc bool, inFlight *atomic.Int32, maxInFlight *atomic.Int32) Fetch[int64] {
+	return func(_ context.Context, rng *client.FilterRange, offset, limit int64) ([]int64, error) {
+		if inFlight != nil {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+		}
+		from, to := int64(0), count-1
+		if rng != nil {
+			from, to = *rng.From, min(*rng.To, count-1)
+		}
+		var all []int64
+		for n := from; n <= to; n++ {
+			all = append(all, n)
+		}
+		if desc {
+			for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+				all[i], all[j] = all[j], all[i]
+			}
+		}
+		if offset >= int64(len(all)) {
+			return []int64{}, nil
+		}
+		return all[offset:min(offset+limit, int64(len(all)))], nil
+	}
+}
+
+func bestBlock(number int64) Best {
+	return func(context.Context) (int64, error) { return number, nil }
+}
+
+func blockRange(from, to int64) *client.FilterRange {
+	return &client.FilterRange{From: &from, To: &to}
+}
+
+func TestIterator_Order(t *testing.T) {
+	for _, desc := range []bool{false, true} {
+		var inFlight, maxInFlight atomic.Int32
+		opts := Options{PageSize: 7, ChunkSize: 10, Concurrency: 3}
+		it := New(context.Background(), blockRange(5, 1000), desc, opts, bestBlock(94), logs(1000, desc, &inFlight, &maxInFlight))
+
+		var pages int
+		var all []int64
+		for it.Next() {
+			pages++
+			assert.LessOrEqual(t, len(it.Page()), 7)
+			all = append(all, it.Page()...)
+		}
+		assert.NoError(t, it.Err())
+
+		want := make([]int64, 0, 90)
+		for n := int64(5); n <= 94; n++ {
+			want = append(want, n)
+		}
+		if desc {
+			for i, j := 0, len(want)-1; i < j; i, j = i+1, j-1 {
+				want[i], want[j] = want[j], want[i]
+			}
+		}
+		assert.Equal(t, want, all, "the range is bounded by the best block and delivered in order")
+		assert.Greater(t, pages, 9)
+		assert.Equal(t, int32(3), maxInFlight.Load())
+	}
+}
+
+func TestIterator_UnboundedRange(t *testing.T) {
+	var calls atomic.Int32
+	fetch := logs(20, false, nil, nil)
+	it := New(context.Background(), nil, false, Options{PageSize: 8}, nil,
+		func(ctx context.Context, rng *client.FilterRange, offset, limit int64) ([]int64, error) {
+			calls.Add(1)
+			return fetch(ctx, rng, offset, limit)
+		})
+
+	all, err := it.All()
+	assert.NoError(t, err)
+	assert.Len(t, all, 20)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestIterator_InvertedRange(t *testing.T) {
+	fetch := func(context.Context, *client.FilterRange, int64, int64) ([]int64, error) {
+		return nil, errors.New("an inverted range must not be queried")
+	}
+	// the range starts after the best block
+	all, err := New(context.Background(), blockRange(50, 100), false, Options{}, bestBlock(20), fetch).All()
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+
+	all, err = New(context.Background(), blockRange(10, 5), false, Options{}, bestBlock(20), fetch).All()
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestIterator_Error(t *testing.T) {
+	boom := errors.New("boom")
+	var once sync.Once
+	fetch := logs(100, false, nil, nil)
+	it := New(context.Background(), blockRange(0, 99), false, Options{PageSize: 5, ChunkSize: 10}, bestBlock(99),
+		func(ctx context.Context, rng *client.FilterRange, offset, limit int64) ([]int64, error) {
+			if *rng.From == 30 {
+				var err error
+				once.Do(func() { err = boom })
+				if err != nil {
+					return nil, err
+				}
+			}
+			return fetch(ctx, rng, offset, limit)
+		})
+
+	all, err := it.All()
+	assert.ErrorIs(t, err, boom)
+	assert.Len(t, all, 30, "the chunks before the failed one are delivered")
+	assert.False(t, it.Next())
+}
+
+func TestIterator_Close(t *testing.T) {
+	it := New(context.Background(), blockRange(0, 999), false, Options{PageSize: 1, ChunkSize: 10}, bestBlock(999),
+		logs(1000, false, nil, nil))
+	assert.True(t, it.Next())
+	it.Close()
+	for it.Next() {
+	}
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
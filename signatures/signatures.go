@@ -0,0 +1,388 @@
+// Package signatures decodes calls and logs of contracts whose ABI is unknown. It maps function selectors and
+// event topics to the signatures of common contracts: the ERC20/VIP180, ERC721 and ERC1155 tokens, the Thor
+// builtins, and the OpenZeppelin access control (AccessControl, Ownable) and proxies (ERC1967).
+//
+// A selector or a topic can match several signatures, so decoding is best effort and returns every candidate
+// which decodes the data, the most likely first.
+package signatures
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrUnknownSignature is returned when no signature of the database decodes the data.
+var ErrUnknownSignature = errors.New("signatures: no known signature matches")
+
+//go:embed data/*.json
+var data embed.FS
+
+// standards are the embedded ABIs, with the sources which define them.
+var standards = []struct {
+	file    string
+	sources []string
+}{
+	{"data/erc20.json", []string{"ERC20", "VIP180"}},
+	{"data/erc721.json", []string{"ERC721"}},
+	{"data/erc1155.json", []string{"ERC1155"}},
+	{"data/access_control.json", []string{"AccessControl"}},
+	{"data/ownable.json", []string{"Ownable"}},
+	{"data/erc1967.json", []string{"ERC1967"}},
+}
+
+type source struct {
+	name string
+	abi  *abi.ABI
+}
+
+var (
+	embeddedOnce sync.Once
+	embedded     []source
+)
+
+// embeddedSources parses the embedded ABIs once.
+func embeddedSources() []source {
+	embeddedOnce.Do(func() {
+		for _, standard := range standards {
+			raw, err := data.ReadFile(standard.file)
+			if err != nil {
+				panic(fmt.Errorf("read %q: %v", standard.file, err))
+			}
+			parsed, err := abi.JSON(bytes.NewReader(raw))
+			if err != nil {
+				panic(fmt.Errorf("parse %q: %v", standard.file, err))
+			}
+			for _, name := range standard.sources {
+				embedded = append(embedded, source{name: name, abi: &parsed})
+			}
+		}
+		embedded = append(embedded,
+			source{"VTHO", builtins.VTHO.ABI},
+			source{"Authority", builtins.Authority.ABI},
+			source{"Executor", builtins.Executor.ABI},
+			source{"Extension", builtins.Extension.ABI},
+			source{"Prototype", builtins.Prototype.ABI},
+			source{"Params", builtins.Params.ABI},
+		)
+	})
+	return embedded
+}
+
+// DB maps function selectors and event topics to signatures. It is not safe for concurrent use while entries are
+// added, but once populated, it can decode concurrently.
+type DB struct {
+	functions map[[4]byte][]*function
+	events    map[common.Hash][]*event
+	added     int
+}
+
+type function struct {
+	method  abi.Method
+	sources []string
+	rank    rank
+}
+
+type event struct {
+	event   abi.Event
+	key     string
+	sources []string
+	rank    rank
+}
+
+// rank orders the candidates which decode the same data.
+type rank struct {
+	// user is true for the entries added with Add
+	user bool
+	// added is the order of the entry in the database
+	added int
+}
+
+// New creates an empty database.
+func New() *DB {
+	return &DB{functions: make(map[[4]byte][]*function), events: make(map[common.Hash][]*event)}
+}
+
+// Default creates a database holding the embedded signatures. Each call returns a new database, so entries added
+// to it don't affect the others.
+func Default() *DB {
+	db := New()
+	for _, src := range embeddedSources() {
+		db.add(src.name, src.abi, false)
+	}
+	return db
+}
+
+// Add adds the functions and events of an ABI, naming source the contract or standard which defines them. The
+// entries added with Add are ranked before the embedded ones. A signature which is already known only gets the
+// new source.
+func (db *DB) Add(source string, contractABI *abi.ABI) {
+	db.add(source, contractABI, true)
+}
+
+// AddJSON is like Add, for an ABI in JSON.
+func (db *DB) AddJSON(source string, abiJSON []byte) error {
+	parsed, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("signatures: parse %s: %w", source, err)
+	}
+	db.Add(source, &parsed)
+	return nil
+}
+
+func (db *DB) add(source string, contractABI *abi.ABI, user bool) {
+	for _, method := range contractABI.Methods {
+		var selector [4]byte
+		copy(selector[:], method.ID)
+		if i := slices.IndexFunc(db.functions[selector], func(f *function) bool { return f.method.Sig == method.Sig }); i >= 0 {
+			db.functions[selector][i].addSource(source, user)
+			continue
+		}
+		db.added++
+		db.functions[selector] = append(db.functions[selector], &function{
+			method:  method,
+			sources: []string{source},
+			rank:    rank{user: user, added: db.added},
+		})
+	}
+	for _, ev := range contractABI.Events {
+		if ev.Anonymous {
+			continue
+		}
+		key := eventKey(&ev)
+		if i := slices.IndexFunc(db.events[ev.ID], func(e *event) bool { return e.key == key }); i >= 0 {
+			db.events[ev.ID][i].addSource(source, user)
+			continue
+		}
+		db.added++
+		db.events[ev.ID] = append(db.events[ev.ID], &event{
+			event:   ev,
+			key:     key,
+			sources: []string{source},
+			rank:    rank{user: user, added: db.added},
+		})
+	}
+}
+
+func (f *function) addSource(source string, user bool) {
+	if !slices.Contains(f.sources, source) {
+		f.sources = append(f.sources, source)
+	}
+	f.rank.user = f.rank.user || user
+}
+
+func (e *event) addSource(source string, user bool) {
+	if !slices.Contains(e.sources, source) {
+		e.sources = append(e.sources, source)
+	}
+	e.rank.user = e.rank.user || user
+}
+
+// eventKey identifies an event by its signature and its indexed inputs, which both define how its logs are
+// decoded.
+func eventKey(ev *abi.Event) string {
+	key := ev.Sig
+	for _, input := range ev.Inputs {
+		if input.Indexed {
+			key += " i"
+		} else {
+			key += " d"
+		}
+	}
+	return key
+}
+
+// Call is a candidate decoding of call data.
+type Call struct {
+	// Name is the name of the function.
+	Name string
+	// Signature is the canonical signature of the function, such as "transfer(address,uint256)".
+	Signature string
+	// Sources are the contracts or standards which define the function.
+	Sources []string
+	// Args are the arguments of the call, by input name, or "arg0", "arg1"... for unnamed inputs.
+	Args map[string]interface{}
+	// Exact is true when the data is exactly the encoding of the arguments. A function whose selector collides
+	// can decode data it didn't encode, as long as the data is long enough.
+	Exact bool
+}
+
+// Log is a candidate decoding of an event log.
+type Log struct {
+	// Name is the name of the event.
+	Name string
+	// Signature is the canonical signature of the event, such as "Transfer(address,address,uint256)".
+	Signature string
+	// Sources are the contracts or standards which define the event.
+	Sources []string
+	// Args are the inputs of the event, by name, or "arg0", "arg1"... for unnamed inputs, numbered by position.
+	// Indexed inputs of dynamic types are the common.Hash stored in the topic.
+	Args map[string]interface{}
+	// Exact is true when the data of the log is exactly the encoding of the non-indexed inputs.
+	Exact bool
+}
+
+// DecodeCall decodes call data, which starts with the selector of the function. The candidates which decode the
+// data are returned, ranked by exactness, then the entries added with Add, then the number of sources defining
+// the signature, then the most recently added. ErrUnknownSignature is returned if none decodes the data.
+func (db *DB) DecodeCall(data []byte) ([]Call, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("signatures: call data of %d bytes has no selector", len(data))
+	}
+	var selector [4]byte
+	copy(selector[:], data)
+
+	var calls []candidate[Call]
+	for _, f := range db.functions[selector] {
+		values, err := f.method.Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		packed, err := f.method.Inputs.Pack(values...)
+		exact := err == nil && bytes.Equal(packed, data[4:])
+		calls = append(calls, candidate[Call]{
+			value: Call{
+				Name:      f.method.RawName,
+				Signature: f.method.Sig,
+				Sources:   slices.Clone(f.sources),
+				Args:      args(f.method.Inputs, values),
+				Exact:     exact,
+			},
+			exact:   exact,
+			sources: len(f.sources),
+			rank:    f.rank,
+		})
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("%w: selector %s", ErrUnknownSignature, hexutil.Encode(selector[:]))
+	}
+	return sorted(calls), nil
+}
+
+// DecodeClause decodes the data of a clause. See DecodeCall.
+func (db *DB) DecodeClause(clause *tx.Clause) ([]Call, error) {
+	return db.DecodeCall(clause.Data())
+}
+
+// DecodeLog decodes an event log by its first topic. The candidates are returned and ranked as for DecodeCall.
+func (db *DB) DecodeLog(log client.EventLog) ([]Log, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: the log has no topic", ErrUnknownSignature)
+	}
+	raw, err := hexutil.Decode(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("signatures: decode data: %w", err)
+	}
+
+	var logs []candidate[Log]
+	for _, e := range db.events[log.Topics[0]] {
+		var indexed, nonIndexed abi.Arguments
+		for i, input := range e.event.Inputs {
+			// unnamed inputs are named by their position among all the inputs, indexed or not
+			input.Name = argName(input, i)
+			if input.Indexed {
+				indexed = append(indexed, input)
+			} else {
+				nonIndexed = append(nonIndexed, input)
+			}
+		}
+		if len(indexed) != len(log.Topics)-1 {
+			continue
+		}
+		values, err := nonIndexed.Unpack(raw)
+		if err != nil {
+			continue
+		}
+		decoded := args(nonIndexed, values)
+		topics := make(map[string]interface{})
+		if err := abi.ParseTopicsIntoMap(topics, indexed, log.Topics[1:]); err != nil {
+			continue
+		}
+		for name, value := range topics {
+			decoded[name] = value
+		}
+		packed, err := nonIndexed.Pack(values...)
+		exact := err == nil && bytes.Equal(packed, raw)
+		logs = append(logs, candidate[Log]{
+			value: Log{
+				Name:      e.event.RawName,
+				Signature: e.event.Sig,
+				Sources:   slices.Clone(e.sources),
+				Args:      decoded,
+				Exact:     exact,
+			},
+			exact:   exact,
+			sources: len(e.sources),
+			rank:    e.rank,
+		})
+	}
+	if len(logs) == 0 {
+		return nil, fmt.Errorf("%w: topic %s", ErrUnknownSignature, log.Topics[0].Hex())
+	}
+	return sorted(logs), nil
+}
+
+// candidate is a decoding with its rank.
+type candidate[T any] struct {
+	value   T
+	exact   bool
+	sources int
+	rank    rank
+}
+
+// sorted returns the candidates by exactness, entries added with Add, number of sources and recency.
+func sorted[T any](candidates []candidate[T]) []T {
+	slices.SortStableFunc(candidates, func(a, b candidate[T]) int {
+		switch {
+		case a.exact != b.exact:
+			return compareBool(b.exact, a.exact)
+		case a.rank.user != b.rank.user:
+			return compareBool(b.rank.user, a.rank.user)
+		case a.sources != b.sources:
+			return b.sources - a.sources
+		default:
+			return b.rank.added - a.rank.added
+		}
+	})
+	values := make([]T, len(candidates))
+	for i, c := range candidates {
+		values[i] = c.value
+	}
+	return values
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func args(inputs abi.Arguments, values []interface{}) map[string]interface{} {
+	decoded := make(map[string]interface{}, len(inputs))
+	for i, input := range inputs {
+		decoded[argName(input, i)] = values[i]
+	}
+	return decoded
+}
+
+func argName(input abi.Argument, i int) string {
+	if input.Name == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	return input.Name
+}
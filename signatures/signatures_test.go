@@ -0,0 +1,156 @@
+package signatures
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenABI = `[
+	{"type":"function","name":"mint","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"transfer","inputs":[{"name":"recipient","type":"address"},{"name":"amount","type":"uint256"}]}
+]`
+
+func TestDB_DecodeCall(t *testing.T) {
+	db := Default()
+	to := common.HexToAddress("0x0a")
+	data, err := builtins.VTHO.ABI.Pack("transfer", to, big.NewInt(100))
+	assert.NoError(t, err)
+
+	calls, err := db.DecodeClause(tx.NewClause(&builtins.VTHO.Address).WithData(data))
+	assert.NoError(t, err)
+	assert.Len(t, calls, 1)
+	assert.Equal(t, "transfer", calls[0].Name)
+	assert.Equal(t, "transfer(address,uint256)", calls[0].Signature)
+	assert.Equal(t, []string{"ERC20", "VIP180", "VTHO"}, calls[0].Sources)
+	assert.Equal(t, map[string]interface{}{"to": to, "value": big.NewInt(100)}, calls[0].Args)
+	assert.True(t, calls[0].Exact)
+
+	// trailing bytes still decode, but not exactly
+	calls, err = db.DecodeCall(append(data, 1))
+	assert.NoError(t, err)
+	assert.False(t, calls[0].Exact)
+
+	_, err = db.DecodeCall(hexutil.MustDecode("0x12345678"))
+	assert.ErrorIs(t, err, ErrUnknownSignature)
+	_, err = db.DecodeCall(data[:2])
+	assert.Error(t, err)
+
+	// user entries are added to the database
+	assert.NoError(t, db.AddJSON("Token", []byte(tokenABI)))
+	token, err := abi.JSON(strings.NewReader(tokenABI))
+	assert.NoError(t, err)
+	data, err = token.Pack("mint", to, big.NewInt(1))
+	assert.NoError(t, err)
+	calls, err = db.DecodeCall(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "mint", calls[0].Name)
+	assert.Equal(t, []string{"Token"}, calls[0].Sources)
+
+	// a known signature gets the new source
+	data, err = token.Pack("transfer", to, big.NewInt(1))
+	assert.NoError(t, err)
+	calls, err = db.DecodeCall(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ERC20", "VIP180", "VTHO", "Token"}, calls[0].Sources)
+}
+
+func TestDB_Ranking(t *testing.T) {
+	db := Default()
+	data, err := builtins.VTHO.ABI.Pack("transfer", common.HexToAddress("0x0a"), big.NewInt(100))
+	assert.NoError(t, err)
+	selector := [4]byte(data[:4])
+
+	// a colliding selector, whose single input decodes the start of the data
+	address, err := abi.NewType("address", "", nil)
+	assert.NoError(t, err)
+	collision := abi.NewMethod("collision", "collision", abi.Function, "", false, false,
+		abi.Arguments{{Name: "who", Type: address}}, nil)
+	db.functions[selector] = append(db.functions[selector], &function{
+		method:  collision,
+		sources: []string{"Other"},
+		rank:    rank{user: true, added: db.added + 1},
+	})
+
+	calls, err := db.DecodeCall(data)
+	assert.NoError(t, err)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, "transfer", calls[0].Name, "exact decodings come first")
+	assert.Equal(t, "collision", calls[1].Name)
+	assert.False(t, calls[1].Exact)
+
+	// without the exact decoding, user entries come first
+	calls, err = db.DecodeCall(append(data, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "collision", calls[0].Name)
+}
+
+func TestDB_DecodeLog(t *testing.T) {
+	db := Default()
+	from, to := common.HexToAddress("0x0a"), common.HexToAddress("0x0b")
+	erc20 := builtins.VTHO.ABI.Events["Transfer"]
+	value, err := erc20.Inputs.NonIndexed().Pack(big.NewInt(5))
+	assert.NoError(t, err)
+
+	logs, err := db.DecodeLog(client.EventLog{
+		Topics: []common.Hash{erc20.ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:   hexutil.Encode(value),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "Transfer(address,address,uint256)", logs[0].Signature)
+	assert.Equal(t, []string{"ERC20", "VIP180", "VTHO"}, logs[0].Sources)
+	assert.Equal(t, map[string]interface{}{"from": from, "to": to, "value": big.NewInt(5)}, logs[0].Args)
+	assert.True(t, logs[0].Exact)
+
+	// the same signature with the token ID in a topic is an ERC721 transfer
+	logs, err = db.DecodeLog(client.EventLog{
+		Topics: []common.Hash{erc20.ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()),
+			common.BigToHash(big.NewInt(7))},
+		Data: "0x",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, []string{"ERC721"}, logs[0].Sources)
+	assert.Equal(t, big.NewInt(7), logs[0].Args["tokenId"])
+
+	// unnamed inputs are named by their position among all the inputs
+	uint256, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	unnamed := abi.Event{
+		Name:    "Unnamed",
+		RawName: "Unnamed",
+		Sig:     "Unnamed(uint256,uint256,uint256)",
+		ID:      crypto.Keccak256Hash([]byte("Unnamed(uint256,uint256,uint256)")),
+		Inputs: abi.Arguments{
+			{Type: uint256, Indexed: true},
+			{Type: uint256},
+			{Type: uint256, Indexed: true},
+		},
+	}
+	db.Add("Unnamed", &abi.ABI{Events: map[string]abi.Event{"Unnamed": unnamed}})
+	value, err = unnamed.Inputs.NonIndexed().Pack(big.NewInt(2))
+	assert.NoError(t, err)
+	logs, err = db.DecodeLog(client.EventLog{
+		Topics: []common.Hash{unnamed.ID, common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(3))},
+		Data:   hexutil.Encode(value),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, map[string]interface{}{"arg0": big.NewInt(1), "arg1": big.NewInt(2), "arg2": big.NewInt(3)},
+		logs[0].Args)
+
+	_, err = db.DecodeLog(client.EventLog{Topics: []common.Hash{{1}}, Data: "0x"})
+	assert.ErrorIs(t, err, ErrUnknownSignature)
+	_, err = db.DecodeLog(client.EventLog{Data: "0x"})
+	assert.ErrorIs(t, err, ErrUnknownSignature)
+}
@@ -0,0 +1,444 @@
+package simulated
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/internal/logfilter"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+)
+
+var _ client.Backend = (*Backend)(nil)
+
+var errRevisionNotFound = client.NewHttpError(http.StatusBadRequest, "revision: not found")
+
+// GenesisBlock returns the genesis block of the chain.
+func (b *Backend) GenesisBlock() *client.Block {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	genesis := b.blocks[0].Block
+	return &genesis
+}
+
+// ChainTag returns the chain tag of the genesis block.
+func (b *Backend) ChainTag() byte {
+	return b.GenesisBlock().ChainTag()
+}
+
+func (b *Backend) AccountWithContext(ctx context.Context, addr common.Address) (*client.Account, error) {
+	return b.AccountAtWithContext(ctx, addr, client.RevisionBest)
+}
+
+func (b *Backend) AccountAtWithContext(
+	_ context.Context,
+	addr common.Address,
+	revision client.Revision,
+) (*client.Account, error) {
+	var account *client.Account
+	err := b.withState(revision, func(st *state.StateDB, blk *block) {
+		account = &client.Account{
+			Balance: hexutil.Big(*st.GetBalance(addr).ToBig()),
+			Energy:  hexutil.Big(*energyAt(st, addr, uint64(blk.Timestamp))),
+			HasCode: st.GetCodeSize(addr) > 0,
+		}
+	})
+	return account, err
+}
+
+func (b *Backend) InspectWithContext(ctx context.Context, body client.InspectRequest) ([]client.InspectResponse, error) {
+	return b.InspectAtWithContext(ctx, body, client.RevisionBest)
+}
+
+func (b *Backend) InspectAtWithContext(
+	_ context.Context,
+	body client.InspectRequest,
+	revision client.Revision,
+) ([]client.InspectResponse, error) {
+	var (
+		results []client.InspectResponse
+		err     error
+	)
+	stateErr := b.withState(revision, func(st *state.StateDB, blk *block) {
+		results, err = newExecutor(b, st, blk).inspect(body)
+	})
+	if stateErr != nil {
+		return nil, stateErr
+	}
+	return results, err
+}
+
+func (b *Backend) AccountCodeWithContext(ctx context.Context, addr common.Address) (*client.AccountCode, error) {
+	return b.AccountCodeAtWithContext(ctx, addr, client.RevisionBest)
+}
+
+func (b *Backend) AccountCodeAtWithContext(
+	_ context.Context,
+	addr common.Address,
+	revision client.Revision,
+) (*client.AccountCode, error) {
+	var code *client.AccountCode
+	err := b.withState(revision, func(st *state.StateDB, _ *block) {
+		code = &client.AccountCode{Code: hexutil.Encode(st.GetCode(addr))}
+	})
+	return code, err
+}
+
+func (b *Backend) AccountStorageWithContext(
+	ctx context.Context,
+	addr common.Address,
+	key common.Hash,
+) (*client.AccountStorage, error) {
+	return b.AccountStorageAtWithContext(ctx, addr, key, client.RevisionBest)
+}
+
+func (b *Backend) AccountStorageAtWithContext(
+	_ context.Context,
+	addr common.Address,
+	key common.Hash,
+	revision client.Revision,
+) (*client.AccountStorage, error) {
+	var storage *client.AccountStorage
+	err := b.withState(revision, func(st *state.StateDB, _ *block) {
+		storage = &client.AccountStorage{Value: st.GetState(addr, key).Hex()}
+	})
+	return storage, err
+}
+
+// withState calls fn with a copy of the state after the block of the revision. The next revision is the state of
+// the best block seen from the block following it. Changes to the state are discarded.
+func (b *Backend) withState(revision client.Revision, fn func(st *state.StateDB, blk *block)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var blk *block
+	if revision == client.RevisionNext {
+		blk = b.nextBlock(b.best())
+	} else {
+		var err error
+		if blk, err = b.resolve(revision); err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				return errRevisionNotFound
+			}
+			return err
+		}
+	}
+	st, err := b.stateAt(blk)
+	if err != nil {
+		return err
+	}
+	fn(st, blk)
+	return nil
+}
+
+func (b *Backend) BlockWithContext(_ context.Context, revision client.Revision) (*client.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.resolve(revision)
+	if err != nil {
+		return nil, err
+	}
+	view := blk.Block
+	view.Transactions = append([]common.Hash{}, blk.Transactions...)
+	return &view, nil
+}
+
+func (b *Backend) BestBlockWithContext(ctx context.Context) (*client.Block, error) {
+	return b.BlockWithContext(ctx, client.RevisionBest)
+}
+
+func (b *Backend) ExpandedBlockWithContext(_ context.Context, revision client.Revision) (*client.ExpandedBlock, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.resolve(revision)
+	if err != nil {
+		return nil, err
+	}
+	expanded := &client.ExpandedBlock{Block: blk.Block, Transactions: make([]client.BlockTransaction, 0, len(blk.txs))}
+	expanded.Block.Transactions = append([]common.Hash{}, blk.Transactions...)
+	for _, t := range blk.txs {
+		view := newTransaction(t)
+		expanded.Transactions = append(expanded.Transactions, client.BlockTransaction{
+			ID:           view.ID,
+			ChainTag:     byte(view.ChainTag),
+			BlockRef:     view.BlockRef,
+			Expiration:   view.Expiration,
+			Clauses:      view.Clauses,
+			GasPriceCoef: view.GasPriceCoef,
+			Gas:          view.Gas,
+			Origin:       view.Origin,
+			Delegator:    view.Delegator,
+			Nonce:        view.Nonce,
+			DependsOn:    view.DependsOn,
+			Size:         view.Size,
+			GasUsed:      t.receipt.GasUsed,
+			GasPayer:     t.receipt.GasPayer,
+			Paid:         *t.receipt.Paid,
+			Reward:       *t.receipt.Reward,
+			Reverted:     t.receipt.Reverted,
+			Outputs:      t.receipt.Outputs,
+		})
+	}
+	return expanded, nil
+}
+
+// resolve returns the block of a revision. Every block of the simulated chain is final, so the best, justified
+// and finalized revisions are the same block.
+func (b *Backend) resolve(revision client.Revision) (*block, error) {
+	if id, ok := revision.ID(); ok {
+		blk := b.blockByID(id)
+		if blk == nil {
+			return nil, client.ErrNotFound
+		}
+		return blk, nil
+	}
+	if number, ok := revision.Number(); ok {
+		if uint64(number) >= uint64(len(b.blocks)) {
+			return nil, client.ErrNotFound
+		}
+		return b.blocks[number], nil
+	}
+	if revision == client.RevisionNext {
+		return nil, client.NewHttpError(http.StatusBadRequest, "revision: invalid revision")
+	}
+	return b.best(), nil
+}
+
+func (b *Backend) blockByID(id common.Hash) *block {
+	number := uint64(tx.NewBlockRefFromID(id).Number())
+	if number >= uint64(len(b.blocks)) || b.blocks[number].ID != id {
+		return nil
+	}
+	return b.blocks[number]
+}
+
+func (b *Backend) SendTransactionWithContext(
+	_ context.Context,
+	trx *tx.Transaction,
+) (*client.SendTransactionResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, err := b.validate(trx)
+	if err != nil {
+		return nil, err
+	}
+	b.txs[trx.ID()] = t
+	b.pending = append(b.pending, t)
+	if b.config.autoMine {
+		if _, err := b.commit(); err != nil {
+			return nil, err
+		}
+	}
+	return &client.SendTransactionResponse{ID: trx.ID()}, nil
+}
+
+func (b *Backend) SendRawTransactionWithContext(
+	ctx context.Context,
+	raw string,
+) (*client.SendTransactionResponse, error) {
+	encoded, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, client.NewHttpError(http.StatusBadRequest, "raw: "+err.Error())
+	}
+	trx, err := tx.Decode(encoded)
+	if err != nil {
+		return nil, client.NewHttpError(http.StatusBadRequest, "raw: "+err.Error())
+	}
+	return b.SendTransactionWithContext(ctx, trx)
+}
+
+// validate checks a transaction as the transaction pool of Thor does.
+func (b *Backend) validate(trx *tx.Transaction) (*transaction, error) {
+	reject := func(reason string) error {
+		return client.NewHttpError(http.StatusForbidden, reason)
+	}
+
+	best := b.best()
+	if trx.ChainTag() != b.blocks[0].ChainTag() {
+		return nil, reject("bad tx: chain tag mismatch")
+	}
+	origin, err := trx.Origin()
+	if err != nil {
+		return nil, reject("bad tx: invalid signature")
+	}
+	gasPayer := origin
+	delegator, err := trx.Delegator()
+	if err != nil {
+		return nil, reject("bad tx: invalid delegator signature")
+	}
+	if delegator != nil {
+		gasPayer = *delegator
+	}
+	if intrinsic, err := trx.IntrinsicGas(); err != nil || intrinsic > trx.Gas() {
+		return nil, reject("bad tx: intrinsic gas exceeds provided gas")
+	}
+	if _, ok := b.txs[trx.ID()]; ok {
+		return nil, reject("tx rejected: known tx")
+	}
+	if trx.IsExpired(uint32(best.Number + 1)) {
+		return nil, reject("tx rejected: expired")
+	}
+
+	st, err := b.stateAt(best)
+	if err != nil {
+		return nil, err
+	}
+	prepaid := new(big.Int).Mul(trx.GasPrice(b.config.baseGasPrice), new(big.Int).SetUint64(trx.Gas()))
+	if energyAt(st, gasPayer, uint64(b.nextTimestamp(best))).Cmp(prepaid) < 0 {
+		return nil, reject("tx rejected: insufficient energy")
+	}
+	return &transaction{tx: trx, origin: origin, gasPayer: gasPayer}, nil
+}
+
+func (b *Backend) TransactionWithContext(ctx context.Context, id common.Hash) (*client.Transaction, error) {
+	return b.TransactionAtWithContext(ctx, id, common.Hash{})
+}
+
+func (b *Backend) TransactionAtWithContext(
+	_ context.Context,
+	id common.Hash,
+	head common.Hash,
+) (*client.Transaction, error) {
+	var view *client.Transaction
+	err := b.withTransaction(id, head, false, func(t *transaction) {
+		v := newTransaction(t)
+		view = &v
+	})
+	return view, err
+}
+
+func (b *Backend) RawTransactionWithContext(ctx context.Context, id common.Hash) (*client.RawTransaction, error) {
+	return b.RawTransactionAtWithContext(ctx, id, common.Hash{})
+}
+
+func (b *Backend) RawTransactionAtWithContext(
+	_ context.Context,
+	id common.Hash,
+	head common.Hash,
+) (*client.RawTransaction, error) {
+	var raw *client.RawTransaction
+	err := b.withTransaction(id, head, false, func(t *transaction) {
+		encoded, _ := t.tx.Encoded()
+		raw = &client.RawTransaction{Raw: "0x" + encoded, Meta: txMeta(t)}
+	})
+	return raw, err
+}
+
+func (b *Backend) PendingTransactionWithContext(_ context.Context, id common.Hash) (*client.Transaction, error) {
+	var view *client.Transaction
+	err := b.withTransaction(id, common.Hash{}, true, func(t *transaction) {
+		v := newTransaction(t)
+		view = &v
+	})
+	return view, err
+}
+
+func (b *Backend) TransactionReceiptWithContext(
+	ctx context.Context,
+	id common.Hash,
+) (*client.TransactionReceipt, error) {
+	return b.TransactionReceiptAtWithContext(ctx, id, common.Hash{})
+}
+
+func (b *Backend) TransactionReceiptAtWithContext(
+	_ context.Context,
+	id common.Hash,
+	head common.Hash,
+) (*client.TransactionReceipt, error) {
+	var receipt *client.TransactionReceipt
+	err := b.withTransaction(id, head, false, func(t *transaction) {
+		r := *t.receipt
+		receipt = &r
+	})
+	return receipt, err
+}
+
+// withTransaction calls fn with the transaction, or returns client.ErrNotFound if it is unknown, pending while
+// pending is false, or included after the head block. The zero head is the best block.
+func (b *Backend) withTransaction(id, head common.Hash, pending bool, fn func(t *transaction)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	headBlock := b.best()
+	if head != (common.Hash{}) {
+		headBlock = b.blockByID(head)
+		if headBlock == nil {
+			return errRevisionNotFound
+		}
+	}
+	t, ok := b.txs[id]
+	switch {
+	case !ok:
+		return client.ErrNotFound
+	case t.included == nil && !pending:
+		return client.ErrNotFound
+	case t.included != nil && t.included.Number > headBlock.Number:
+		return client.ErrNotFound
+	}
+	fn(t)
+	return nil
+}
+
+func (b *Backend) FilterEventsWithContext(_ context.Context, filter *client.EventFilter) ([]client.EventLog, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return logfilter.Events(b.events, filter), nil
+}
+
+func (b *Backend) FilterTransfersWithContext(
+	_ context.Context,
+	filter *client.TransferFilter,
+) ([]client.TransferLog, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return logfilter.Transfers(b.transfers, filter), nil
+}
+
+// PeersWithContext returns no peers: the simulated chain runs alone.
+func (b *Backend) PeersWithContext(context.Context) ([]client.Peer, error) {
+	return []client.Peer{}, nil
+}
+
+func newTransaction(t *transaction) client.Transaction {
+	trx := t.tx
+	delegator, _ := trx.Delegator()
+	clauses := make([]tx.Clause, 0, len(trx.Clauses()))
+	for _, c := range trx.Clauses() {
+		clauses = append(clauses, *c)
+	}
+	nonce := hexutil.Big{}
+	nonce.ToInt().SetUint64(trx.Nonce())
+
+	return client.Transaction{
+		ID:           trx.ID(),
+		ChainTag:     int64(trx.ChainTag()),
+		BlockRef:     trx.BlockRef(),
+		Expiration:   int64(trx.Expiration()),
+		Clauses:      clauses,
+		GasPriceCoef: int64(trx.GasPriceCoef()),
+		Gas:          int64(trx.Gas()),
+		Origin:       t.origin,
+		Delegator:    delegator,
+		Nonce:        nonce,
+		DependsOn:    trx.DependsOn(),
+		Size:         int64(trx.Size()),
+		Meta:         txMeta(t),
+	}
+}
+
+func txMeta(t *transaction) client.TxMeta {
+	if t.included == nil {
+		return client.TxMeta{}
+	}
+	return client.TxMeta{
+		BlockID:        t.included.ID,
+		BlockNumber:    t.included.Number,
+		BlockTimestamp: t.included.Timestamp,
+	}
+}
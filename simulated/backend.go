@@ -0,0 +1,338 @@
+// Package simulated provides a simulated Thor chain which executes transactions and inspections in-process on the
+// go-ethereum EVM. Like the simulated backend of go-ethereum, it is meant for testing contracts without a node:
+// *Backend implements client.Backend, so it can be passed to thorgo.FromClient and used with accounts.Deployer,
+// Contract.Call and the transaction managers.
+//
+// The chain follows Thor where it matters to applications: accounts hold VET and VTHO, VTHO grows with the VET
+// balance and pays for gas, the clauses of a transaction revert together, contracts deployed by clauses get Thor
+// addresses and fees can be delegated. The VTHO, Prototype, Extension and Params builtins are available, except for
+// the methods depending on governance or sponsorship.
+//
+// The differences with Thor are: gas costs follow the Shanghai rules of Ethereum, contracts created by other
+// contracts get Ethereum addresses, proved work isn't taken into account and blocks are never reorganised, so every
+// block is final.
+package simulated
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/internal/memchain"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlockInterval is the minimum time between the timestamps of two consecutive blocks.
+const BlockInterval = 10 * time.Second
+
+var (
+	// DefaultBaseGasPrice is the base gas price of Thor, in wei of VTHO.
+	DefaultBaseGasPrice = big.NewInt(1e15)
+	// DefaultBlockGasLimit is the gas limit of the blocks.
+	DefaultBlockGasLimit uint64 = 40_000_000
+)
+
+// Account is the genesis state of an account.
+type Account struct {
+	Balance *big.Int
+	Energy  *big.Int
+	Code    []byte
+	Storage map[common.Hash]common.Hash
+}
+
+// GenesisAlloc is the state of the accounts in the genesis block.
+type GenesisAlloc map[common.Address]Account
+
+// SoloAlloc returns the allocation of the solo network: each of the solo keys holds a billion VET and VTHO.
+func SoloAlloc() GenesisAlloc {
+	amount := new(big.Int).Mul(big.NewInt(1e9), big.NewInt(1e18))
+	alloc := make(GenesisAlloc)
+	for _, key := range solo.Keys() {
+		alloc[addressOf(key)] = Account{Balance: amount, Energy: amount}
+	}
+	return alloc
+}
+
+// Option configures a Backend.
+type Option func(*config)
+
+type config struct {
+	autoMine     bool
+	baseGasPrice *big.Int
+	gasLimit     uint64
+}
+
+// WithAutoMine makes the backend commit a block for every transaction it receives, so that the receipt is
+// available as soon as the transaction is sent.
+func WithAutoMine() Option {
+	return func(c *config) {
+		c.autoMine = true
+	}
+}
+
+// WithBaseGasPrice sets the base gas price. Defaults to DefaultBaseGasPrice.
+func WithBaseGasPrice(price *big.Int) Option {
+	return func(c *config) {
+		c.baseGasPrice = new(big.Int).Set(price)
+	}
+}
+
+// WithBlockGasLimit sets the gas limit of the blocks. Defaults to DefaultBlockGasLimit.
+func WithBlockGasLimit(limit uint64) Option {
+	return func(c *config) {
+		c.gasLimit = limit
+	}
+}
+
+// Backend is a simulated Thor chain. It is safe for concurrent use.
+type Backend struct {
+	config config
+	db     state.Database
+
+	mu        sync.Mutex
+	blocks    []*block
+	txs       map[common.Hash]*transaction
+	pending   []*transaction
+	events    []client.EventLog
+	transfers []client.TransferLog
+	timeShift time.Duration
+}
+
+type block struct {
+	client.Block
+	root common.Hash
+	txs  []*transaction
+}
+
+type transaction struct {
+	tx       *tx.Transaction
+	origin   common.Address
+	gasPayer common.Address
+	receipt  *client.TransactionReceipt
+	included *block
+}
+
+// NewBackend creates a simulated chain whose genesis block holds the given accounts. A nil alloc uses SoloAlloc.
+// The genesis block has the ID of the solo network, so that transactions signed for solo are accepted.
+func NewBackend(alloc GenesisAlloc, opts ...Option) (*Backend, error) {
+	cfg := config{baseGasPrice: DefaultBaseGasPrice, gasLimit: DefaultBlockGasLimit}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if alloc == nil {
+		alloc = SoloAlloc()
+	}
+
+	b := &Backend{
+		config: cfg,
+		db:     state.NewDatabaseForTesting(),
+		txs:    make(map[common.Hash]*transaction),
+	}
+	timestamp := time.Now().Add(-BlockInterval).Unix()
+
+	st, err := state.New(types.EmptyRootHash, b.db)
+	if err != nil {
+		return nil, err
+	}
+	setupBuiltins(st)
+	for addr, acc := range alloc {
+		if acc.Balance != nil {
+			st.SetBalance(addr, toUint256(acc.Balance), 0)
+		}
+		energy := new(big.Int)
+		if acc.Energy != nil {
+			energy.Set(acc.Energy)
+		}
+		setEnergy(st, addr, energy, uint64(timestamp))
+		addTotalSupply(st, energy)
+		if len(acc.Code) > 0 {
+			st.SetNonce(addr, 1)
+			st.SetCode(addr, acc.Code)
+		}
+		for key, value := range acc.Storage {
+			st.SetState(addr, key, value)
+		}
+	}
+	root, err := st.Commit(0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	b.blocks = []*block{{
+		Block: client.Block{
+			Number:       0,
+			ID:           solo.GenesisID(),
+			Timestamp:    timestamp,
+			GasLimit:     int64(cfg.gasLimit),
+			StateRoot:    root,
+			IsTrunk:      true,
+			IsFinalized:  true,
+			Transactions: []common.Hash{},
+		},
+		root: root,
+	}}
+	return b, nil
+}
+
+// Commit adds a block containing the pending transactions to the chain and returns it. Transactions which are not
+// executable yet, for example because their dependency is pending, stay in the pool.
+func (b *Backend) Commit() (*client.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blk, err := b.commit()
+	if err != nil {
+		return nil, err
+	}
+	view := blk.Block
+	return &view, nil
+}
+
+// AdjustTime moves the clock of the chain forward, so that the next block is at least d later than it would be.
+func (b *Backend) AdjustTime(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.timeShift += d
+}
+
+// Pending returns the transactions received by the backend which are not yet in a block.
+func (b *Backend) Pending() []*tx.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pending := make([]*tx.Transaction, 0, len(b.pending))
+	for _, t := range b.pending {
+		pending = append(pending, t.tx)
+	}
+	return pending
+}
+
+func (b *Backend) best() *block {
+	return b.blocks[len(b.blocks)-1]
+}
+
+// stateAt opens the state after the given block.
+func (b *Backend) stateAt(blk *block) (*state.StateDB, error) {
+	st, err := state.New(blk.root, b.db)
+	if err != nil {
+		return nil, fmt.Errorf("simulated: state of block %d: %w", blk.Number, err)
+	}
+	return st, nil
+}
+
+// nextTimestamp returns the timestamp of the block following parent: one interval later, or the current time
+// rounded to the interval if the chain is behind the clock.
+func (b *Backend) nextTimestamp(parent *block) int64 {
+	interval := int64(BlockInterval / time.Second)
+	next := parent.Timestamp + interval
+	if now := time.Now().Add(b.timeShift).Unix(); now > next {
+		next = parent.Timestamp + (now-parent.Timestamp)/interval*interval
+	}
+	return next
+}
+
+// nextBlock returns the empty block following parent, sharing the state of parent.
+func (b *Backend) nextBlock(parent *block) *block {
+	number := parent.Number + 1
+	return &block{
+		Block: client.Block{
+			Number:       number,
+			ID:           memchain.BlockID(parent.ID, number),
+			ParentID:     parent.ID,
+			Timestamp:    b.nextTimestamp(parent),
+			GasLimit:     int64(b.config.gasLimit),
+			TotalScore:   number,
+			IsTrunk:      true,
+			IsFinalized:  true,
+			Transactions: []common.Hash{},
+		},
+		root: parent.root,
+	}
+}
+
+func (b *Backend) commit() (*block, error) {
+	parent := b.best()
+	blk := b.nextBlock(parent)
+
+	st, err := b.stateAt(blk)
+	if err != nil {
+		return nil, err
+	}
+
+	var remaining []*transaction
+	for _, t := range b.pending {
+		switch b.readiness(t, blk, st) {
+		case txWait:
+			remaining = append(remaining, t)
+			continue
+		case txDrop:
+			delete(b.txs, t.tx.ID())
+			continue
+		}
+
+		receipt := newExecutor(b, st, blk).apply(t)
+		receipt.Meta = client.ReceiptMeta{
+			BlockID:        blk.ID,
+			BlockNumber:    blk.Number,
+			BlockTimestamp: blk.Timestamp,
+			TxID:           t.tx.ID(),
+			TxOrigin:       t.origin,
+		}
+		t.receipt = receipt
+		t.included = blk
+		blk.txs = append(blk.txs, t)
+		blk.Transactions = append(blk.Transactions, t.tx.ID())
+		blk.GasUsed += receipt.GasUsed
+		b.events, b.transfers = memchain.Index(b.events, b.transfers, receipt, int64(len(blk.txs)-1))
+	}
+
+	root, err := st.Commit(uint64(blk.Number), true)
+	if err != nil {
+		return nil, err
+	}
+	blk.root = root
+	blk.StateRoot = root
+	b.pending = remaining
+	b.blocks = append(b.blocks, blk)
+	return blk, nil
+}
+
+type readiness int
+
+const (
+	txReady readiness = iota
+	txWait
+	txDrop
+)
+
+// readiness tells whether a pending transaction can be added to the block, as the transaction pool of Thor would.
+func (b *Backend) readiness(t *transaction, blk *block, st *state.StateDB) readiness {
+	number := uint32(blk.Number)
+	if t.tx.IsExpired(number) {
+		return txDrop
+	}
+	if t.tx.BlockRef().Number() > number {
+		return txWait
+	}
+	if dep := t.tx.DependsOn(); dep != nil {
+		parent, ok := b.txs[*dep]
+		if !ok || parent.included == nil {
+			return txWait
+		}
+		if parent.receipt.Reverted {
+			return txDrop
+		}
+	}
+	if uint64(blk.GasUsed)+t.tx.Gas() > b.config.gasLimit {
+		return txWait
+	}
+	prepaid := new(big.Int).Mul(t.tx.GasPrice(b.config.baseGasPrice), new(big.Int).SetUint64(t.tx.Gas()))
+	if energyAt(st, t.gasPayer, uint64(blk.Timestamp)).Cmp(prepaid) < 0 {
+		return txWait
+	}
+	return txReady
+}
@@ -0,0 +1,195 @@
+package simulated_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/darrenvechain/thorgo"
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/simulated"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBackend(t *testing.T, opts ...simulated.Option) (*simulated.Backend, *thorgo.Thor) {
+	backend, err := simulated.NewBackend(nil, opts...)
+	assert.NoError(t, err)
+	return backend, thorgo.FromClient(backend)
+}
+
+func TestBackend_DeployAndCall(t *testing.T) {
+	_, thor := newBackend(t, simulated.WithAutoMine())
+	sender := txmanager.FromPK(solo.Keys()[0], thor)
+
+	erc20ABI, err := abi.JSON(strings.NewReader(contractABI))
+	assert.NoError(t, err)
+	erc20, txID, err := thor.Deployer(common.Hex2Bytes(erc20Bytecode), &erc20ABI).Deploy(sender, "MyERC20", "ERC20")
+	assert.NoError(t, err)
+
+	receipt, err := thor.Transaction(txID).Receipt()
+	assert.NoError(t, err)
+	assert.False(t, receipt.Reverted)
+	assert.Equal(t, sender.Address(), receipt.GasPayer)
+	assert.Positive(t, receipt.Paid.ToInt().Sign())
+
+	var name string
+	assert.NoError(t, erc20.Call("name", &name))
+	assert.Equal(t, "MyERC20", name)
+
+	// the master of a contract deployed by a clause is the origin
+	master := new(common.Address)
+	assert.NoError(t, builtins.Prototype.Load(thor).Call("master", master, erc20.Address))
+	assert.Equal(t, sender.Address(), *master)
+
+	recipient := common.HexToAddress("0x1234")
+	mint, err := erc20.Send(sender, "mint", recipient, big.NewInt(1000))
+	assert.NoError(t, err)
+	receipt, err = mint.Wait()
+	assert.NoError(t, err)
+	assert.False(t, receipt.Reverted)
+	assert.Len(t, receipt.Outputs[0].Events, 1)
+
+	balance := new(big.Int)
+	assert.NoError(t, erc20.Call("balanceOf", &balance, recipient))
+	assert.Equal(t, big.NewInt(1000), balance)
+
+	events, err := thor.Backend.FilterEventsWithContext(context.Background(), &client.EventFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, erc20.Address, *events[0].Address)
+}
+
+func TestBackend_ClausesRevertTogether(t *testing.T) {
+	backend, thor := newBackend(t)
+	sender := txmanager.FromPK(solo.Keys()[0], thor)
+	recipient := common.HexToAddress("0x5678")
+
+	vtho := builtins.VTHO.Load(thor)
+	transfer, err := vtho.AsClause("transfer", recipient, big.NewInt(10))
+	assert.NoError(t, err)
+	tooMuch, err := vtho.AsClause("transfer", recipient, new(big.Int).Lsh(big.NewInt(1), 200))
+	assert.NoError(t, err)
+
+	visitor, err := thor.Transactor([]*tx.Clause{transfer, tooMuch}).Gas(200_000).Send(sender)
+	assert.NoError(t, err)
+	_, err = visitor.Receipt()
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	_, err = backend.Commit()
+	assert.NoError(t, err)
+	receipt, err := visitor.Receipt()
+	assert.NoError(t, err)
+	assert.True(t, receipt.Reverted)
+	assert.Empty(t, receipt.Outputs)
+
+	acc, err := thor.Account(recipient).Get()
+	assert.NoError(t, err)
+	assert.Zero(t, acc.Energy.ToInt().Sign())
+}
+
+func TestBackend_VETAndDelegation(t *testing.T) {
+	backend, thor := newBackend(t, simulated.WithAutoMine())
+	origin := txmanager.FromPK(solo.Keys()[1], thor)
+	gasPayer := txmanager.NewDelegator(solo.Keys()[2])
+	sender := txmanager.NewDelegatedManager(thor, origin, gasPayer)
+	recipient := common.HexToAddress("0x9abc")
+
+	payerBefore, err := thor.Account(crypto.PubkeyToAddress(solo.Keys()[2].PublicKey)).Get()
+	assert.NoError(t, err)
+
+	amount := big.NewInt(1e18)
+	id, err := sender.SendClauses([]*tx.Clause{tx.NewClause(&recipient).WithValue(amount)})
+	assert.NoError(t, err)
+	receipt, err := thor.Transaction(id).Receipt()
+	assert.NoError(t, err)
+	assert.Equal(t, gasPayer.Address(), receipt.GasPayer)
+	assert.Equal(t, []client.Transfer{{
+		Sender:    origin.Address(),
+		Recipient: recipient,
+		Amount:    receipt.Outputs[0].Transfers[0].Amount,
+	}}, receipt.Outputs[0].Transfers)
+	assert.Equal(t, amount, receipt.Outputs[0].Transfers[0].Amount.ToInt())
+
+	acc, err := thor.Account(recipient).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, amount, acc.Balance.ToInt())
+	acc, err = thor.Account(recipient).Revision(client.RevisionNumber(0)).Get()
+	assert.NoError(t, err)
+	assert.Zero(t, acc.Balance.ToInt().Sign())
+
+	// VTHO grows with VET
+	backend.AdjustTime(simulated.BlockInterval * 10)
+	_, err = backend.Commit()
+	assert.NoError(t, err)
+	acc, err = thor.Account(recipient).Get()
+	assert.NoError(t, err)
+	assert.Positive(t, acc.Energy.ToInt().Sign())
+	next, err := thor.Account(recipient).Revision(client.RevisionNext).Get()
+	assert.NoError(t, err)
+	assert.True(t, next.Energy.ToInt().Cmp(acc.Energy.ToInt()) > 0, "VTHO keeps growing in the next block")
+
+	payerAfter, err := thor.Account(gasPayer.Address()).Get()
+	assert.NoError(t, err)
+	paid := new(big.Int).Sub(payerBefore.Energy.ToInt(), payerAfter.Energy.ToInt())
+	assert.True(t, paid.Cmp(receipt.Paid.ToInt()) <= 0, "the gas payer earns VTHO while paying")
+
+	transfers, err := thor.Backend.FilterTransfersWithContext(context.Background(), &client.TransferFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+}
+
+func TestBackend_Inspect(t *testing.T) {
+	_, thor := newBackend(t)
+	caller := crypto.PubkeyToAddress(solo.Keys()[0].PublicKey)
+
+	txGasPayer := builtins.Extension.ABI.Methods["txGasPayer"].ID
+	blake, err := builtins.Extension.ABI.Pack("blake2b256", []byte("thor"))
+	assert.NoError(t, err)
+	results, err := thor.Backend.InspectWithContext(context.Background(), client.InspectRequest{
+		Caller: &caller,
+		Clauses: []*tx.Clause{
+			tx.NewClause(&builtins.Extension.Address).WithData(txGasPayer),
+			tx.NewClause(&builtins.Extension.Address).WithData(blake),
+			tx.NewClause(&builtins.Authority.Address).WithData([]byte{1, 2, 3, 4}),
+			tx.NewClause(&builtins.Extension.Address).WithData(txGasPayer),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 3, "execution stops at the first reverted clause")
+	assert.Equal(t, common.BytesToHash(caller.Bytes()).Hex(), results[0].Data)
+	assert.False(t, results[1].Reverted)
+	assert.True(t, results[2].Reverted)
+	assert.Equal(t, "execution reverted", results[2].VmError)
+
+	var symbol string
+	assert.NoError(t, builtins.VTHO.Load(thor).Call("symbol", &symbol))
+	assert.Equal(t, "VTHO", symbol)
+}
+
+func TestBackend_Rejections(t *testing.T) {
+	_, thor := newBackend(t)
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	poor := txmanager.FromPK(key, thor)
+	recipient := common.HexToAddress("0x1234")
+
+	_, err = thor.Transactor([]*tx.Clause{tx.NewClause(&recipient)}).Gas(21_000).Send(poor)
+	assert.ErrorIs(t, err, client.ErrInsufficientEnergy)
+
+	_, err = thor.Backend.BlockWithContext(context.Background(), client.RevisionNumber(100))
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	_, err = thor.Backend.AccountAtWithContext(context.Background(), recipient, client.RevisionID(common.HexToHash("0x01")))
+	assert.ErrorIs(t, err, client.ErrRevisionNotFound)
+	_, err = thor.Backend.AccountAtWithContext(context.Background(), recipient, client.RevisionNumber(100))
+	assert.ErrorIs(t, err, client.ErrRevisionNotFound)
+	_, err = thor.Backend.BlockWithContext(context.Background(), client.RevisionNext)
+	assert.ErrorIs(t, err, client.ErrInvalidRevision)
+}
@@ -0,0 +1,342 @@
+package simulated
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/crypto/hash"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// builtinContracts are the builtin contracts of Thor. Those without methods revert on every call.
+var builtinContracts = []*builtins.Contract{
+	builtins.VTHO,
+	builtins.Authority,
+	builtins.Executor,
+	builtins.Extension,
+	builtins.Prototype,
+	builtins.Params,
+}
+
+// Gas charged by the builtin methods.
+const (
+	gasPure  = params.SloadGasFrontier
+	gasRead  = params.SloadGasEIP2200
+	gasWrite = params.SstoreResetGasEIP2200
+	gasEvent = params.LogGas + 3*params.LogTopicGas + 32*params.LogDataGas
+)
+
+// revertError makes a builtin revert with the given reason.
+type revertError string
+
+func (r revertError) Error() string {
+	return string(r)
+}
+
+var (
+	errNotSupported     = revertError("builtin: not supported by the simulated backend")
+	errInsufficientVTHO = revertError("builtin: insufficient balance")
+)
+
+var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+type builtinMethod struct {
+	gas uint64
+	run func(e *executor, f *frame, args []any) ([]any, error)
+}
+
+// builtin serves the calls to a builtin contract as a precompiled contract of the EVM.
+type builtin struct {
+	contract *builtins.Contract
+	methods  map[string]builtinMethod
+	e        *executor
+}
+
+func newBuiltins(e *executor) map[common.Address]vm.PrecompiledContract {
+	methods := map[*builtins.Contract]map[string]builtinMethod{
+		builtins.VTHO:      energyMethods,
+		builtins.Extension: extensionMethods,
+		builtins.Prototype: prototypeMethods,
+		builtins.Params:    paramsMethods,
+	}
+	contracts := make(map[common.Address]vm.PrecompiledContract, len(builtinContracts))
+	for _, c := range builtinContracts {
+		contracts[c.Address] = &builtin{contract: c, methods: methods[c], e: e}
+	}
+	return contracts
+}
+
+func (b *builtin) RequiredGas(input []byte) uint64 {
+	if len(input) < 4 {
+		return 0
+	}
+	m, err := b.contract.ABI.MethodById(input[:4])
+	if err != nil {
+		return 0
+	}
+	return b.methods[m.Name].gas
+}
+
+func (b *builtin) Run(input []byte) ([]byte, error) {
+	if len(input) < 4 {
+		return revert(errNotSupported)
+	}
+	m, err := b.contract.ABI.MethodById(input[:4])
+	if err != nil {
+		return revert(errNotSupported)
+	}
+	impl, ok := b.methods[m.Name]
+	if !ok {
+		return revert(errNotSupported)
+	}
+	f := b.e.frame()
+	if !m.IsConstant() && f.static {
+		return nil, vm.ErrWriteProtection
+	}
+	args, err := m.Inputs.Unpack(input[4:])
+	if err != nil {
+		return revert(revertError("builtin: invalid input"))
+	}
+
+	out, err := impl.run(b.e, f, args)
+	var reason revertError
+	if errors.As(err, &reason) {
+		return revert(reason)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.Outputs.Pack(out...)
+}
+
+// revert encodes the reason as Solidity does for require and revert.
+func revert(reason revertError) ([]byte, error) {
+	data, _ := abi.Arguments{{Type: stringType}}.Pack(string(reason))
+	return append(append([]byte{}, revertSelector...), data...), vm.ErrExecutionReverted
+}
+
+var stringType, _ = abi.NewType("string", "", nil)
+
+var energyMethods = map[string]builtinMethod{
+	"name": {gasPure, func(*executor, *frame, []any) ([]any, error) {
+		return []any{"VeThor"}, nil
+	}},
+	"symbol": {gasPure, func(*executor, *frame, []any) ([]any, error) {
+		return []any{"VTHO"}, nil
+	}},
+	"decimals": {gasPure, func(*executor, *frame, []any) ([]any, error) {
+		return []any{uint8(18)}, nil
+	}},
+	"totalSupply": {gasRead, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{stored(e.st, totalSupplyKey)}, nil
+	}},
+	"totalBurned": {gasRead, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{stored(e.st, totalBurnedKey)}, nil
+	}},
+	"balanceOf": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		return []any{energyAt(e.st, args[0].(common.Address), e.time)}, nil
+	}},
+	"allowance": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		key := allowanceKey(args[0].(common.Address), args[1].(common.Address))
+		return []any{e.st.GetState(builtins.VTHO.Address, key).Big()}, nil
+	}},
+	"transfer": {2*gasWrite + gasEvent, func(e *executor, f *frame, args []any) ([]any, error) {
+		return []any{true}, e.transferEnergy(f.caller, args[0].(common.Address), args[1].(*big.Int))
+	}},
+	"approve": {gasWrite + gasEvent, func(e *executor, f *frame, args []any) ([]any, error) {
+		spender, amount := args[0].(common.Address), args[1].(*big.Int)
+		e.st.SetState(builtins.VTHO.Address, allowanceKey(f.caller, spender), common.BigToHash(amount))
+		e.emit(builtins.VTHO, "Approval", f.caller, spender, amount)
+		return []any{true}, nil
+	}},
+	"transferFrom": {3*gasWrite + gasEvent, func(e *executor, f *frame, args []any) ([]any, error) {
+		from, to, amount := args[0].(common.Address), args[1].(common.Address), args[2].(*big.Int)
+		key := allowanceKey(from, f.caller)
+		allowance := e.st.GetState(builtins.VTHO.Address, key).Big()
+		if allowance.Cmp(amount) < 0 {
+			return nil, revertError("builtin: insufficient allowance")
+		}
+		e.st.SetState(builtins.VTHO.Address, key, common.BigToHash(allowance.Sub(allowance, amount)))
+		return []any{true}, e.transferEnergy(from, to, amount)
+	}},
+	"move": {2*gasWrite + gasEvent, func(e *executor, f *frame, args []any) ([]any, error) {
+		from, to, amount := args[0].(common.Address), args[1].(common.Address), args[2].(*big.Int)
+		if f.caller != from && f.caller != e.master(from) {
+			return nil, revertError("builtin: self or master required")
+		}
+		return []any{true}, e.transferEnergy(from, to, amount)
+	}},
+}
+
+var extensionMethods = map[string]builtinMethod{
+	"blake2b256": {params.Keccak256Gas, func(_ *executor, _ *frame, args []any) ([]any, error) {
+		return []any{[32]byte(hash.Blake2b(args[0].([]byte)))}, nil
+	}},
+	"blockID": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		var id [32]byte
+		if blk := e.blockAt(args[0].(*big.Int)); blk != nil {
+			id = blk.ID
+		}
+		return []any{id}, nil
+	}},
+	"blockTotalScore": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		num := args[0].(*big.Int)
+		if num.IsUint64() && num.Uint64() == uint64(e.number) {
+			return []any{uint64(e.number)}, nil
+		}
+		if blk := e.blockAt(num); blk != nil {
+			return []any{uint64(blk.TotalScore)}, nil
+		}
+		return []any{uint64(0)}, nil
+	}},
+	"blockTime": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		num := args[0].(*big.Int)
+		if num.IsUint64() && num.Uint64() == uint64(e.number) {
+			return []any{new(big.Int).SetUint64(e.time)}, nil
+		}
+		if blk := e.blockAt(num); blk != nil {
+			return []any{big.NewInt(blk.Timestamp)}, nil
+		}
+		return []any{new(big.Int)}, nil
+	}},
+	// blocks of the simulated chain are not signed
+	"blockSigner": {gasRead, func(*executor, *frame, []any) ([]any, error) {
+		return []any{common.Address{}}, nil
+	}},
+	"totalSupply": {gasRead, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{stored(e.st, totalSupplyKey)}, nil
+	}},
+	"txID": {gasPure, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{[32]byte(e.txID)}, nil
+	}},
+	"txBlockRef": {gasPure, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{[8]byte(e.blockRef)}, nil
+	}},
+	"txExpiration": {gasPure, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{new(big.Int).SetUint64(uint64(e.expiration))}, nil
+	}},
+	"txProvedWork": {gasPure, func(*executor, *frame, []any) ([]any, error) {
+		return []any{new(big.Int)}, nil
+	}},
+	"txGasPayer": {gasPure, func(e *executor, _ *frame, _ []any) ([]any, error) {
+		return []any{e.gasPayer}, nil
+	}},
+}
+
+var prototypeMethods = map[string]builtinMethod{
+	"master": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		return []any{e.master(args[0].(common.Address))}, nil
+	}},
+	"setMaster": {gasWrite, func(e *executor, f *frame, args []any) ([]any, error) {
+		self, master := args[0].(common.Address), args[1].(common.Address)
+		if f.caller != self && f.caller != e.master(self) {
+			return nil, revertError("builtin: self or master required")
+		}
+		e.st.SetState(builtins.Prototype.Address, masterKey(self), common.BytesToHash(master.Bytes()))
+		return nil, nil
+	}},
+	"hasCode": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		return []any{e.st.GetCodeSize(args[0].(common.Address)) > 0}, nil
+	}},
+	"storageFor": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		return []any{[32]byte(e.st.GetState(args[0].(common.Address), args[1].([32]byte)))}, nil
+	}},
+	"balance": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		addr := args[0].(common.Address)
+		st, _, err := e.stateAt(args[1].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+		return []any{st.GetBalance(addr).ToBig()}, nil
+	}},
+	"energy": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		addr := args[0].(common.Address)
+		st, time, err := e.stateAt(args[1].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+		return []any{energyAt(st, addr, time)}, nil
+	}},
+}
+
+// paramsValues are the governance parameters known to the simulated backend.
+var paramsValues = map[common.Hash]func(e *executor) *big.Int{
+	common.BytesToHash([]byte("base-gas-price")): func(e *executor) *big.Int {
+		return e.backend.config.baseGasPrice
+	},
+	common.BytesToHash([]byte("reward-ratio")): func(*executor) *big.Int {
+		return new(big.Int).Mul(big.NewInt(rewardRatio), big.NewInt(1e16))
+	},
+}
+
+var paramsMethods = map[string]builtinMethod{
+	"get": {gasRead, func(e *executor, _ *frame, args []any) ([]any, error) {
+		if value, ok := paramsValues[args[0].([32]byte)]; ok {
+			return []any{value(e)}, nil
+		}
+		return []any{new(big.Int)}, nil
+	}},
+	"executor": {gasPure, func(*executor, *frame, []any) ([]any, error) {
+		return []any{builtins.Executor.Address}, nil
+	}},
+}
+
+// transferEnergy moves VTHO between accounts and emits the Transfer event of the VTHO contract.
+func (e *executor) transferEnergy(from, to common.Address, amount *big.Int) error {
+	balance := energyAt(e.st, from, e.time)
+	if balance.Cmp(amount) < 0 {
+		return errInsufficientVTHO
+	}
+	setEnergy(e.st, from, balance.Sub(balance, amount), e.time)
+	received := energyAt(e.st, to, e.time)
+	setEnergy(e.st, to, received.Add(received, amount), e.time)
+	e.emit(builtins.VTHO, "Transfer", from, to, amount)
+	return nil
+}
+
+// emit adds an event of a builtin contract, whose indexed arguments are addresses.
+func (e *executor) emit(c *builtins.Contract, name string, from, to common.Address, amount *big.Int) {
+	e.st.AddLog(&types.Log{
+		Address: c.Address,
+		Topics: []common.Hash{
+			c.ABI.Events[name].ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data:        common.BigToHash(amount).Bytes(),
+		BlockNumber: uint64(e.number),
+	})
+}
+
+func (e *executor) master(addr common.Address) common.Address {
+	return common.BytesToAddress(e.st.GetState(builtins.Prototype.Address, masterKey(addr)).Bytes())
+}
+
+// blockAt returns a block preceding the one being executed, or nil.
+func (e *executor) blockAt(number *big.Int) *block {
+	if !number.IsUint64() {
+		return nil
+	}
+	return e.block(number.Uint64())
+}
+
+// stateAt returns the state after a block preceding the one being executed, or the current state, with the
+// time to compute energy at.
+func (e *executor) stateAt(number *big.Int) (*state.StateDB, uint64, error) {
+	blk := e.blockAt(number)
+	if blk == nil {
+		return e.st, e.time, nil
+	}
+	st, err := e.backend.stateAt(blk)
+	if err != nil {
+		return nil, 0, err
+	}
+	return st, uint64(blk.Timestamp), nil
+}
@@ -0,0 +1,111 @@
+package simulated
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// As on Thor, the VTHO balances live in the storage of the Energy builtin rather than in the accounts, so that they
+// are journaled, reverted and committed with the rest of the state. A balance is stored with the time it was last
+// updated, and grows with the VET balance of the account from then on.
+
+// energyGrowthRate is the wei of VTHO generated per VET per second.
+var energyGrowthRate = big.NewInt(5_000_000_000)
+
+var (
+	totalSupplyKey = crypto.Keccak256Hash([]byte("total-supply"))
+	totalBurnedKey = crypto.Keccak256Hash([]byte("total-burned"))
+)
+
+func energyKey(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash(addr.Bytes(), []byte("energy"))
+}
+
+func energyTimeKey(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash(addr.Bytes(), []byte("energy-time"))
+}
+
+func allowanceKey(owner, spender common.Address) common.Hash {
+	return crypto.Keccak256Hash(owner.Bytes(), spender.Bytes(), []byte("allowance"))
+}
+
+func masterKey(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash(addr.Bytes(), []byte("master"))
+}
+
+// energyAt returns the VTHO balance of an account at the given time.
+func energyAt(st *state.StateDB, addr common.Address, time uint64) *big.Int {
+	stored := st.GetState(builtins.VTHO.Address, energyKey(addr)).Big()
+	since := st.GetState(builtins.VTHO.Address, energyTimeKey(addr)).Big().Uint64()
+	// accounts which were never settled never held VET, since every change of balance settles first
+	if since == 0 || time <= since {
+		return stored
+	}
+	return stored.Add(stored, growth(st.GetBalance(addr).ToBig(), time-since))
+}
+
+// growth returns the VTHO generated by a VET balance over the given number of seconds.
+func growth(balance *big.Int, seconds uint64) *big.Int {
+	g := new(big.Int).Mul(balance, energyGrowthRate)
+	g.Mul(g, new(big.Int).SetUint64(seconds))
+	return g.Div(g, big.NewInt(1e18))
+}
+
+// settleEnergy stores the VTHO generated by an account up to the given time. It must be called before the VET
+// balance of the account changes.
+func settleEnergy(st *state.StateDB, addr common.Address, time uint64) *big.Int {
+	previous := st.GetState(builtins.VTHO.Address, energyKey(addr)).Big()
+	energy := energyAt(st, addr, time)
+	addTotalSupply(st, new(big.Int).Sub(energy, previous))
+	setEnergy(st, addr, energy, time)
+	return energy
+}
+
+func setEnergy(st *state.StateDB, addr common.Address, amount *big.Int, time uint64) {
+	st.SetState(builtins.VTHO.Address, energyKey(addr), common.BigToHash(amount))
+	st.SetState(builtins.VTHO.Address, energyTimeKey(addr), common.BigToHash(new(big.Int).SetUint64(time)))
+}
+
+func addTotalSupply(st *state.StateDB, amount *big.Int) {
+	addStored(st, totalSupplyKey, amount)
+}
+
+func addTotalBurned(st *state.StateDB, amount *big.Int) {
+	addStored(st, totalBurnedKey, amount)
+}
+
+func addStored(st *state.StateDB, key common.Hash, amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	total := st.GetState(builtins.VTHO.Address, key).Big()
+	st.SetState(builtins.VTHO.Address, key, common.BigToHash(total.Add(total, amount)))
+}
+
+func stored(st *state.StateDB, key common.Hash) *big.Int {
+	return st.GetState(builtins.VTHO.Address, key).Big()
+}
+
+// setupBuiltins gives the builtin accounts a nonce and code, so that they are not removed as empty accounts and
+// contracts see them as contracts. Calls to them never run the code: they are served by precompiles.
+func setupBuiltins(st *state.StateDB) {
+	for _, c := range builtinContracts {
+		st.SetNonce(c.Address, 1)
+		st.SetCode(c.Address, []byte{0xfe})
+	}
+}
+
+func toUint256(v *big.Int) *uint256.Int {
+	u, _ := uint256.FromBig(v)
+	return u
+}
+
+func addressOf(key *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
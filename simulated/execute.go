@@ -0,0 +1,381 @@
+package simulated
+
+import (
+	"encoding/binary"
+	"errors"
+	"maps"
+	"math/big"
+
+	"github.com/darrenvechain/thorgo/builtins"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/hash"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// rewardRatio is the part of the fees paid to the beneficiary of the block, in percent. The rest is burned.
+const rewardRatio = 30
+
+// chainConfig enables the Ethereum rules up to Shanghai, which the EVM of Thor is compatible with for contracts.
+var chainConfig = &params.ChainConfig{
+	ChainID:             big.NewInt(1),
+	HomesteadBlock:      new(big.Int),
+	EIP150Block:         new(big.Int),
+	EIP155Block:         new(big.Int),
+	EIP158Block:         new(big.Int),
+	ByzantiumBlock:      new(big.Int),
+	ConstantinopleBlock: new(big.Int),
+	PetersburgBlock:     new(big.Int),
+	IstanbulBlock:       new(big.Int),
+	BerlinBlock:         new(big.Int),
+	LondonBlock:         new(big.Int),
+	ShanghaiTime:        new(uint64),
+}
+
+// executor runs clauses against a state, in the context of a block and of a transaction.
+type executor struct {
+	backend *Backend
+	st      *state.StateDB
+	number  uint32
+	time    uint64
+
+	txID       common.Hash
+	blockRef   tx.BlockRef
+	expiration uint32
+	origin     common.Address
+	gasPayer   common.Address
+	gasPrice   *big.Int
+
+	// frames is the stack of calls being executed, which tells the builtins who called them.
+	frames    []*frame
+	transfers []client.Transfer
+}
+
+type frame struct {
+	caller    common.Address
+	static    bool
+	transfers []client.Transfer
+}
+
+func newExecutor(b *Backend, st *state.StateDB, blk *block) *executor {
+	return &executor{
+		backend:  b,
+		st:       st,
+		number:   uint32(blk.Number),
+		time:     uint64(blk.Timestamp),
+		gasPrice: b.config.baseGasPrice,
+	}
+}
+
+// apply executes a transaction on top of the state, charging its fees, and returns its receipt without metadata.
+// If a clause fails, every clause is reverted.
+func (e *executor) apply(t *transaction) *client.TransactionReceipt {
+	trx := t.tx
+	e.txID = trx.ID()
+	e.blockRef = trx.BlockRef()
+	e.expiration = trx.Expiration()
+	e.origin = t.origin
+	e.gasPayer = t.gasPayer
+	e.gasPrice = trx.GasPrice(e.backend.config.baseGasPrice)
+
+	// prepay the gas, the payer was checked to hold enough energy
+	prepaid := new(big.Int).Mul(e.gasPrice, new(big.Int).SetUint64(trx.Gas()))
+	energy := settleEnergy(e.st, t.gasPayer, e.time)
+	setEnergy(e.st, t.gasPayer, energy.Sub(energy, prepaid), e.time)
+
+	intrinsic, _ := trx.IntrinsicGas()
+	left := trx.Gas() - intrinsic
+	snapshot := e.st.Snapshot()
+
+	reverted := false
+	outputs := make([]client.Output, 0, len(trx.Clauses()))
+	for i, clause := range trx.Clauses() {
+		res := e.execute(clause, uint32(i), left)
+		left = res.left
+		if res.err != nil {
+			reverted = true
+			break
+		}
+		outputs = append(outputs, client.Output{
+			ContractAddress: res.contractAddress(),
+			Events:          res.events,
+			Transfers:       res.transfers,
+		})
+	}
+	if reverted {
+		e.st.RevertToSnapshot(snapshot)
+		outputs = []client.Output{}
+	}
+
+	used := trx.Gas() - left
+	used -= min(e.st.GetRefund(), used/2)
+	paid := new(big.Int).Mul(e.gasPrice, new(big.Int).SetUint64(used))
+	reward := new(big.Int).Div(new(big.Int).Mul(paid, big.NewInt(rewardRatio)), big.NewInt(100))
+
+	energy = energyAt(e.st, t.gasPayer, e.time)
+	setEnergy(e.st, t.gasPayer, energy.Add(energy, new(big.Int).Sub(prepaid, paid)), e.time)
+	beneficiary := settleEnergy(e.st, common.Address{}, e.time)
+	setEnergy(e.st, common.Address{}, beneficiary.Add(beneficiary, reward), e.time)
+	addTotalBurned(e.st, new(big.Int).Sub(paid, reward))
+	e.st.Finalise(true)
+
+	return &client.TransactionReceipt{
+		GasUsed:  int64(used),
+		GasPayer: t.gasPayer,
+		Paid:     (*hexutil.Big)(paid),
+		Reward:   (*hexutil.Big)(reward),
+		Reverted: reverted,
+		Outputs:  outputs,
+	}
+}
+
+// inspect executes the clauses of an inspection and returns a result per clause. Execution stops at the first
+// clause which fails, and nothing is charged.
+func (e *executor) inspect(req client.InspectRequest) ([]client.InspectResponse, error) {
+	if req.Caller != nil {
+		e.origin = *req.Caller
+	}
+	e.gasPayer = e.origin
+	if req.GasPayer != nil {
+		e.gasPayer = *req.GasPayer
+	}
+	if req.GasPrice != nil {
+		e.gasPrice = new(big.Int).SetUint64(*req.GasPrice)
+	}
+	if req.Expiration != nil {
+		e.expiration = uint32(*req.Expiration)
+	}
+	if req.BlockRef != nil {
+		ref, err := hexutil.Decode(*req.BlockRef)
+		if err != nil || len(ref) != len(e.blockRef) {
+			return nil, client.NewHttpError(400, "blockRef: invalid block reference")
+		}
+		copy(e.blockRef[:], ref)
+	}
+	left := uint64(inspectGasLimit)
+	if req.Gas != nil {
+		left = *req.Gas
+	}
+
+	results := make([]client.InspectResponse, 0, len(req.Clauses))
+	for i, clause := range req.Clauses {
+		res := e.execute(clause, uint32(i), left)
+		result := client.InspectResponse{
+			Data:      hexutil.Encode(res.ret),
+			Events:    []client.Event{},
+			Transfers: []client.Transfer{},
+			GasUsed:   left - res.left,
+		}
+		left = res.left
+		if res.err != nil {
+			result.Reverted = true
+			result.VmError = res.err.Error()
+			results = append(results, result)
+			break
+		}
+		result.Events = res.events
+		result.Transfers = res.transfers
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// inspectGasLimit is the gas available to inspections which don't set it, as on the API of Thor.
+const inspectGasLimit = 50_000_000
+
+type result struct {
+	ret       []byte
+	left      uint64
+	err       error
+	contract  *common.Address
+	events    []client.Event
+	transfers []client.Transfer
+}
+
+func (r *result) contractAddress() string {
+	if r.contract == nil {
+		return ""
+	}
+	return r.contract.Hex()
+}
+
+// execute runs a clause with the given gas. The state changes of a failed clause are reverted.
+func (e *executor) execute(clause *tx.Clause, index uint32, gas uint64) result {
+	logKey := crypto.Keccak256Hash(e.txID.Bytes(), binary.BigEndian.AppendUint32(nil, index))
+	e.st.SetTxContext(logKey, int(index))
+	e.frames = nil
+	e.transfers = nil
+
+	evm := e.newEVM()
+	rules := evm.ChainConfig().Rules(evm.Context.BlockNumber, true, evm.Context.Time)
+	e.st.Prepare(rules, e.origin, common.Address{}, clause.To(), vm.ActivePrecompiles(rules), nil)
+
+	value := toUint256(clause.Value())
+	res := result{}
+	if to := clause.To(); to != nil {
+		res.ret, res.left, res.err = evm.Call(vm.AccountRef(e.origin), *to, clause.Data(), gas, value)
+	} else {
+		addr := contractAddress(e.txID, index, 0)
+		res.contract = &addr
+		res.ret, res.left, res.err = e.deploy(evm, addr, clause.Data(), gas, value)
+	}
+	if res.err != nil {
+		return res
+	}
+
+	res.events = []client.Event{}
+	for _, log := range e.st.GetLogs(logKey, uint64(e.number), common.Hash{}) {
+		res.events = append(res.events, client.Event{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    hexutil.Encode(log.Data),
+		})
+	}
+	res.transfers = append([]client.Transfer{}, e.transfers...)
+	return res
+}
+
+// deploy creates a contract at the Thor address of a clause: the creation code runs as the code of the new account,
+// then is replaced by the code it returns. The master of the contract is the origin of the transaction.
+func (e *executor) deploy(evm *vm.EVM, addr common.Address, code []byte, gas uint64, value *uint256.Int) (
+	[]byte,
+	uint64,
+	error,
+) {
+	if e.st.GetNonce(addr) != 0 || len(e.st.GetCode(addr)) != 0 {
+		return nil, 0, vm.ErrContractAddressCollision
+	}
+	snapshot := e.st.Snapshot()
+	e.st.SetNonce(addr, 1)
+	e.st.SetCode(addr, code)
+
+	ret, left, err := evm.Call(vm.AccountRef(e.origin), addr, nil, gas, value)
+	switch {
+	case err != nil:
+	case len(ret) > params.MaxCodeSize:
+		err = vm.ErrMaxCodeSizeExceeded
+	case len(ret) > 0 && ret[0] == 0xef:
+		err = vm.ErrInvalidCode
+	case left < uint64(len(ret))*params.CreateDataGas:
+		err = vm.ErrCodeStoreOutOfGas
+	}
+	if err != nil {
+		e.st.RevertToSnapshot(snapshot)
+		if !errors.Is(err, vm.ErrExecutionReverted) {
+			left = 0
+		}
+		return ret, left, err
+	}
+
+	e.st.SetCode(addr, ret)
+	e.st.SetState(builtins.Prototype.Address, masterKey(addr), common.BytesToHash(e.origin.Bytes()))
+	return nil, left - uint64(len(ret))*params.CreateDataGas, nil
+}
+
+func (e *executor) newEVM() *vm.EVM {
+	blockCtx := vm.BlockContext{
+		CanTransfer: func(db vm.StateDB, addr common.Address, amount *uint256.Int) bool {
+			return db.GetBalance(addr).Cmp(amount) >= 0
+		},
+		Transfer:    e.transfer,
+		GetHash:     e.blockHash,
+		BlockNumber: new(big.Int).SetUint64(uint64(e.number)),
+		Time:        e.time,
+		Difficulty:  new(big.Int),
+		GasLimit:    e.backend.config.gasLimit,
+		BaseFee:     new(big.Int),
+		Random:      &common.Hash{},
+	}
+	txCtx := vm.TxContext{Origin: e.origin, GasPrice: e.gasPrice}
+	hooks := &tracing.Hooks{OnEnter: e.enter, OnExit: e.exit}
+	evm := vm.NewEVM(blockCtx, txCtx, e.st, chainConfig, vm.Config{Tracer: hooks, NoBaseFee: true})
+
+	rules := chainConfig.Rules(blockCtx.BlockNumber, true, blockCtx.Time)
+	precompiles := maps.Clone(vm.ActivePrecompiledContracts(rules))
+	for addr, b := range newBuiltins(e) {
+		precompiles[addr] = b
+	}
+	evm.SetPrecompiles(precompiles)
+	return evm
+}
+
+// transfer moves VET, settling the VTHO generated by both accounts first, and records the transfer in the current
+// call frame.
+func (e *executor) transfer(_ vm.StateDB, sender, recipient common.Address, amount *uint256.Int) {
+	if amount.IsZero() {
+		return
+	}
+	settleEnergy(e.st, sender, e.time)
+	settleEnergy(e.st, recipient, e.time)
+	e.st.SubBalance(sender, amount, tracing.BalanceChangeTransfer)
+	e.st.AddBalance(recipient, amount, tracing.BalanceChangeTransfer)
+
+	if f := e.frame(); f != nil {
+		f.transfers = append(f.transfers, client.Transfer{
+			Sender:    sender,
+			Recipient: recipient,
+			Amount:    (*hexutil.Big)(amount.ToBig()),
+		})
+	}
+}
+
+func (e *executor) enter(_ int, typ byte, from, _ common.Address, _ []byte, _ uint64, _ *big.Int) {
+	static := vm.OpCode(typ) == vm.STATICCALL
+	if f := e.frame(); f != nil {
+		static = static || f.static
+	}
+	e.frames = append(e.frames, &frame{caller: from, static: static})
+}
+
+// exit pops the current call frame. The transfers of a successful call are kept by its caller, or by the clause
+// for the outermost call.
+func (e *executor) exit(_ int, _ []byte, _ uint64, _ error, reverted bool) {
+	f := e.frames[len(e.frames)-1]
+	e.frames = e.frames[:len(e.frames)-1]
+	if reverted {
+		return
+	}
+	if parent := e.frame(); parent != nil {
+		parent.transfers = append(parent.transfers, f.transfers...)
+	} else {
+		e.transfers = f.transfers
+	}
+}
+
+func (e *executor) frame() *frame {
+	if len(e.frames) == 0 {
+		return nil
+	}
+	return e.frames[len(e.frames)-1]
+}
+
+// blockHash returns the ID of a block preceding the one being executed, or the zero hash.
+func (e *executor) blockHash(number uint64) common.Hash {
+	if blk := e.block(number); blk != nil {
+		return blk.ID
+	}
+	return common.Hash{}
+}
+
+// block returns a block preceding the one being executed, or nil.
+func (e *executor) block(number uint64) *block {
+	if number >= uint64(e.number) || number >= uint64(len(e.backend.blocks)) {
+		return nil
+	}
+	return e.backend.blocks[number]
+}
+
+// contractAddress derives the address of a contract deployed by a clause, as Thor does.
+func contractAddress(txID common.Hash, clauseIndex, creationCount uint32) common.Address {
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], clauseIndex)
+	binary.BigEndian.PutUint32(b[4:], creationCount)
+	return common.BytesToAddress(hash.Blake2b(txID.Bytes(), b[:4], b[4:]).Bytes())
+}
@@ -1,6 +1,9 @@
 package thorgo
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/darrenvechain/thorgo/accounts"
 	"github.com/darrenvechain/thorgo/blocks"
 	"github.com/darrenvechain/thorgo/client"
@@ -14,49 +17,63 @@ import (
 
 type Thor struct {
 	Blocks *blocks.Blocks
+	// Client is the client Thor was created with. It is nil when Thor was created from another client.Backend.
 	Client *client.Client
+	// Backend is the backend every request goes through, which is Client when it is set.
+	Backend client.Backend
+}
+
+func FromURL(url string, opts ...client.Option) (*Thor, error) {
+	return FromURLWithContext(context.Background(), url, opts...)
 }
 
-func FromURL(url string) (*Thor, error) {
-	c, err := client.FromURL(url)
+// FromURLWithContext is like FromURL but uses the given context to connect to the node.
+func FromURLWithContext(ctx context.Context, url string, opts ...client.Option) (*Thor, error) {
+	c, err := client.NewWithContext(ctx, url, &http.Client{}, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Thor{Client: c, Blocks: blocks.New(c)}, nil
+	return FromClient(c), nil
 }
 
-func FromClient(c *client.Client) *Thor {
-	return &Thor{Client: c, Blocks: blocks.New(c)}
+// FromClient creates a Thor from a *client.Client, or from any other client.Backend such as a decorated client
+// or a simulated backend.
+func FromClient(c client.Backend) *Thor {
+	thor := &Thor{Backend: c, Blocks: blocks.New(c)}
+	if cc, ok := c.(*client.Client); ok {
+		thor.Client = cc
+	}
+	return thor
 }
 
 // Account can be used to query account information such as balance, code, storage, etc.
 // It also provides a way to interact with contracts.
 func (t *Thor) Account(address common.Address) *accounts.Visitor {
-	return accounts.New(t.Client, address)
+	return accounts.New(t.Backend, address)
 }
 
 // Transaction provides utility functions to fetch or wait for transactions and their receipts.
 func (t *Thor) Transaction(hash common.Hash) *transactions.Visitor {
-	return transactions.New(t.Client, hash)
+	return transactions.New(t.Backend, hash)
 }
 
 // Transactor creates a new transaction builder which makes it easier to build, simulate, build and send transactions.
 func (t *Thor) Transactor(clauses []*tx.Clause) *transactions.Transactor {
-	return transactions.NewTransactor(t.Client, clauses)
+	return transactions.NewTransactor(t.Backend, clauses)
 }
 
 // Events sets up a query builder to fetch smart contract solidity events.
 func (t *Thor) Events(criteria []client.EventCriteria) *events.Filter {
-	return events.New(t.Client, criteria)
+	return events.New(t.Backend, criteria)
 }
 
 // Transfers sets up a query builder to fetch VET transfers.
 func (t *Thor) Transfers(criteria []client.TransferCriteria) *transfers.Filter {
-	return transfers.New(t.Client, criteria)
+	return transfers.New(t.Backend, criteria)
 }
 
 // Deployer makes it easier to deploy contracts.
 func (t *Thor) Deployer(bytecode []byte, abi *abi.ABI) *accounts.Deployer {
-	return accounts.NewDeployer(t.Client, bytecode, abi)
+	return accounts.NewDeployer(t.Backend, bytecode, abi)
 }
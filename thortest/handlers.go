@@ -0,0 +1,446 @@
+package thortest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/internal/logfilter"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var errRevisionNotFound = errors.New("revision: not found")
+
+func (n *Node) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /blocks/{revision}", n.getBlock)
+	mux.HandleFunc("GET /accounts/{address}", n.getAccount)
+	mux.HandleFunc("GET /accounts/{address}/code", n.getCode)
+	mux.HandleFunc("GET /accounts/{address}/storage/{key}", n.getStorage)
+	mux.HandleFunc("POST /accounts/*", n.postInspect)
+	mux.HandleFunc("POST /transactions", n.postTransaction)
+	mux.HandleFunc("GET /transactions/{id}", n.getTransaction)
+	mux.HandleFunc("GET /transactions/{id}/receipt", n.getReceipt)
+	mux.HandleFunc("POST /logs/event", n.postEventLogs)
+	mux.HandleFunc("POST /logs/transfer", n.postTransferLogs)
+	mux.HandleFunc("GET /node/network/peers", n.getPeers)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f := n.fault(r.Method, r.URL.Path); f != nil {
+			writeError(w, f.Status, f.Body)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (n *Node) getBlock(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	b, err := n.resolve(r.PathValue("revision"))
+	if errors.Is(err, errRevisionNotFound) {
+		writeJSON(w, nil)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("expanded") != "true" {
+		writeJSON(w, n.blockView(b))
+		return
+	}
+	expanded := expandedBlock{Block: n.blockView(b), Transactions: make([]blockTransaction, 0, len(b.txs))}
+	for _, t := range b.txs {
+		expanded.Transactions = append(expanded.Transactions, newBlockTransaction(t))
+	}
+	writeJSON(w, expanded)
+}
+
+func (n *Node) getAccount(w http.ResponseWriter, r *http.Request) {
+	n.withAccount(w, r, func(acc *account) any {
+		return acc.Account
+	})
+}
+
+func (n *Node) getCode(w http.ResponseWriter, r *http.Request) {
+	n.withAccount(w, r, func(acc *account) any {
+		return client.AccountCode{Code: hexutil.Encode(acc.code)}
+	})
+}
+
+func (n *Node) getStorage(w http.ResponseWriter, r *http.Request) {
+	key, err := parseHash(r.PathValue("key"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "key: "+err.Error())
+		return
+	}
+	n.withAccount(w, r, func(acc *account) any {
+		return client.AccountStorage{Value: acc.storage[key].Hex()}
+	})
+}
+
+// withAccount validates the address and revision of an account request and writes the view of the account.
+func (n *Node) withAccount(w http.ResponseWriter, r *http.Request, view func(*account) any) {
+	if !common.IsHexAddress(r.PathValue("address")) {
+		writeError(w, http.StatusBadRequest, "address: invalid address")
+		return
+	}
+	addr := common.HexToAddress(r.PathValue("address"))
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := n.resolveState(r.URL.Query().Get("revision")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	acc, ok := n.accounts[addr]
+	if !ok {
+		acc = &account{storage: map[common.Hash]common.Hash{}}
+	}
+	writeJSON(w, view(acc))
+}
+
+func (n *Node) postInspect(w http.ResponseWriter, r *http.Request) {
+	var req client.InspectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "body: "+err.Error())
+		return
+	}
+	revision := r.URL.Query().Get("revision")
+
+	n.mu.Lock()
+	err := n.resolveState(revision)
+	inspect := n.inspect
+	n.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := inspect(req, revision)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, results)
+}
+
+func (n *Node) postTransaction(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Raw string `json:"raw"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "body: "+err.Error())
+		return
+	}
+	raw, err := hexutil.Decode(body.Raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "raw: "+err.Error())
+		return
+	}
+	trx, err := tx.Decode(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "raw: "+err.Error())
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id := trx.ID()
+	best := n.blocks[len(n.blocks)-1]
+	switch {
+	case trx.ChainTag() != n.blocks[0].ChainTag():
+		writeError(w, http.StatusForbidden, "bad tx: chain tag mismatch")
+		return
+	case n.txs[id] != nil:
+		writeError(w, http.StatusForbidden, "tx rejected: known tx")
+		return
+	case trx.IsExpired(uint32(best.Number + 1)):
+		writeError(w, http.StatusForbidden, "tx rejected: expired")
+		return
+	}
+	origin, err := trx.Origin()
+	if err != nil {
+		writeError(w, http.StatusForbidden, "bad tx: invalid signature")
+		return
+	}
+	gasPayer := origin
+	delegator, err := trx.Delegator()
+	if err != nil {
+		writeError(w, http.StatusForbidden, "bad tx: invalid delegator signature")
+		return
+	}
+	if delegator != nil {
+		gasPayer = *delegator
+	}
+
+	t := &transaction{tx: trx, origin: origin, gasPayer: gasPayer}
+	n.txs[id] = t
+	n.pending = append(n.pending, t)
+	if n.autoMine {
+		n.mine()
+	}
+	writeJSON(w, client.SendTransactionResponse{ID: id})
+}
+
+func (n *Node) getTransaction(w http.ResponseWriter, r *http.Request) {
+	n.withTransaction(w, r, func(t *transaction) any {
+		var meta *client.TxMeta
+		if t.included != nil {
+			meta = &client.TxMeta{
+				BlockID:        t.included.ID,
+				BlockNumber:    t.included.Number,
+				BlockTimestamp: t.included.Timestamp,
+			}
+		}
+		if r.URL.Query().Get("raw") == "true" {
+			encoded, _ := t.tx.Encoded()
+			return rawTransaction{Raw: "0x" + encoded, Meta: meta}
+		}
+		return newTransaction(t, meta)
+	})
+}
+
+func (n *Node) getReceipt(w http.ResponseWriter, r *http.Request) {
+	n.withTransaction(w, r, func(t *transaction) any {
+		if t.receipt == nil {
+			return nil
+		}
+		return t.receipt
+	})
+}
+
+// withTransaction looks up the transaction of the request and writes its view, or null if it is unknown,
+// pending while "pending" is not set, or included after the "head" block.
+func (n *Node) withTransaction(w http.ResponseWriter, r *http.Request, view func(*transaction) any) {
+	id, err := parseHash(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id: "+err.Error())
+		return
+	}
+	query := r.URL.Query()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	head := n.blocks[len(n.blocks)-1]
+	revision := query.Get("head")
+	if revision == "" {
+		revision = query.Get("revision")
+	}
+	if revision != "" {
+		head, err = n.resolve(revision)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	t, ok := n.txs[id]
+	switch {
+	case !ok:
+		writeJSON(w, nil)
+	case t.included == nil && query.Get("pending") != "true":
+		writeJSON(w, nil)
+	case t.included != nil && t.included.Number > head.Number:
+		writeJSON(w, nil)
+	default:
+		writeJSON(w, view(t))
+	}
+}
+
+func (n *Node) postEventLogs(w http.ResponseWriter, r *http.Request) {
+	var filter client.EventFilter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		writeError(w, http.StatusBadRequest, "body: "+err.Error())
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	writeJSON(w, logfilter.Events(n.events, &filter))
+}
+
+func (n *Node) postTransferLogs(w http.ResponseWriter, r *http.Request) {
+	var filter client.TransferFilter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		writeError(w, http.StatusBadRequest, "body: "+err.Error())
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	writeJSON(w, logfilter.Transfers(n.transfers, &filter))
+}
+
+func (n *Node) getPeers(w http.ResponseWriter, _ *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	writeJSON(w, n.peers)
+}
+
+// resolveState validates the revision of an account or inspect request, which may also be "next".
+func (n *Node) resolveState(revision string) error {
+	if revision == "next" {
+		return nil
+	}
+	_, err := n.resolve(revision)
+	return err
+}
+
+// resolve returns the block of a revision: "best", "justified", "finalized", a block number or a block ID.
+// An empty revision is the best block.
+func (n *Node) resolve(revision string) (*block, error) {
+	switch revision {
+	case "", "best":
+		return n.blocks[len(n.blocks)-1], nil
+	case "justified", "finalized":
+		return n.blocks[n.finalized], nil
+	}
+
+	if len(revision) == 66 && strings.HasPrefix(revision, "0x") {
+		id, err := parseHash(revision)
+		if err != nil {
+			return nil, errors.New("revision: " + err.Error())
+		}
+		number := int64(id[0])<<24 | int64(id[1])<<16 | int64(id[2])<<8 | int64(id[3])
+		if number < int64(len(n.blocks)) && n.blocks[number].ID == id {
+			return n.blocks[number], nil
+		}
+		if b, ok := n.orphans[id]; ok {
+			return b, nil
+		}
+		return nil, errRevisionNotFound
+	}
+
+	number, err := strconv.ParseUint(revision, 0, 32)
+	if err != nil {
+		return nil, errors.New("revision: invalid block number")
+	}
+	if number >= uint64(len(n.blocks)) {
+		return nil, errRevisionNotFound
+	}
+	return n.blocks[number], nil
+}
+
+// transactionJSON is the transaction as served by Thor: the block reference is a hex string and
+// the metadata is null while the transaction is pending.
+type transactionJSON struct {
+	client.Transaction
+	BlockRef string         `json:"blockRef"`
+	Meta     *client.TxMeta `json:"meta"`
+}
+
+type rawTransaction struct {
+	Raw  string         `json:"raw"`
+	Meta *client.TxMeta `json:"meta"`
+}
+
+type blockTransaction struct {
+	client.BlockTransaction
+	BlockRef string `json:"blockRef"`
+}
+
+type expandedBlock struct {
+	client.Block
+	Transactions []blockTransaction `json:"transactions"`
+}
+
+func newTransaction(t *transaction, meta *client.TxMeta) transactionJSON {
+	trx := t.tx
+	delegator, _ := trx.Delegator()
+	clauses := make([]tx.Clause, 0, len(trx.Clauses()))
+	for _, c := range trx.Clauses() {
+		clauses = append(clauses, *c)
+	}
+	nonce := hexutil.Big{}
+	nonce.ToInt().SetUint64(trx.Nonce())
+
+	return transactionJSON{
+		Transaction: client.Transaction{
+			ID:           trx.ID(),
+			ChainTag:     int64(trx.ChainTag()),
+			Expiration:   int64(trx.Expiration()),
+			Clauses:      clauses,
+			GasPriceCoef: int64(trx.GasPriceCoef()),
+			Gas:          int64(trx.Gas()),
+			Origin:       t.origin,
+			Delegator:    delegator,
+			Nonce:        nonce,
+			DependsOn:    trx.DependsOn(),
+			Size:         int64(trx.Size()),
+		},
+		BlockRef: blockRef(trx),
+		Meta:     meta,
+	}
+}
+
+func newBlockTransaction(t *transaction) blockTransaction {
+	view := newTransaction(t, nil)
+	return blockTransaction{
+		BlockTransaction: client.BlockTransaction{
+			ID:           view.ID,
+			ChainTag:     t.tx.ChainTag(),
+			Expiration:   view.Expiration,
+			Clauses:      view.Clauses,
+			GasPriceCoef: view.GasPriceCoef,
+			Gas:          view.Gas,
+			Origin:       view.Origin,
+			Delegator:    view.Delegator,
+			Nonce:        view.Nonce,
+			DependsOn:    view.DependsOn,
+			Size:         view.Size,
+			GasUsed:      t.receipt.GasUsed,
+			GasPayer:     t.receipt.GasPayer,
+			Paid:         bigOrZero(t.receipt.Paid),
+			Reward:       bigOrZero(t.receipt.Reward),
+			Reverted:     t.receipt.Reverted,
+			Outputs:      t.receipt.Outputs,
+		},
+		BlockRef: view.BlockRef,
+	}
+}
+
+func blockRef(trx *tx.Transaction) string {
+	ref := trx.BlockRef()
+	return hexutil.Encode(ref[:])
+}
+
+func bigOrZero(b *hexutil.Big) hexutil.Big {
+	if b == nil {
+		return hexutil.Big{}
+	}
+	return *b
+}
+
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(b) != common.HashLength {
+		return common.Hash{}, errors.New("invalid length")
+	}
+	return common.BytesToHash(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, message)
+}
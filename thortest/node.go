@@ -0,0 +1,415 @@
+// Package thortest provides an in-process fake Thor node for unit tests.
+//
+// The node serves the REST API used by the client package from an in-memory chain which tests script:
+// transactions sent to the node are added to a block with Mine, accounts and inspection results are canned,
+// and faults can be injected for any endpoint. The state of accounts doesn't depend on the revision.
+package thortest
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/internal/memchain"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockInterval is the time between the timestamps of two consecutive blocks of the fake chain.
+const BlockInterval = 10 * time.Second
+
+// InspectFunc computes the results of an inspection. revision is the raw "revision" query parameter.
+type InspectFunc func(req client.InspectRequest, revision string) ([]client.InspectResponse, error)
+
+// Fault makes the node answer matching requests with an error.
+type Fault struct {
+	// Method matches the HTTP method of the request. Empty matches any method.
+	Method string
+	// Path matches the prefix of the request path, for example "/transactions". Empty matches any path.
+	Path string
+	// Status is the status code of the response.
+	Status int
+	// Body is the body of the response, for example "tx rejected: pool is full".
+	Body string
+	// Times is the number of matching requests that fail. Zero fails every matching request.
+	Times int
+}
+
+// Node is a fake Thor node. It is safe for concurrent use.
+type Node struct {
+	tb     testing.TB
+	server *httptest.Server
+
+	mu        sync.Mutex
+	blocks    []*block
+	orphans   map[common.Hash]*block
+	finalized int64
+	txs       map[common.Hash]*transaction
+	pending   []*transaction
+	receipts  map[common.Hash]client.TransactionReceipt
+	accounts  map[common.Address]*account
+	events    []client.EventLog
+	transfers []client.TransferLog
+	peers     []client.Peer
+	inspect   InspectFunc
+	faults    []*Fault
+	autoMine  bool
+}
+
+type block struct {
+	client.Block
+	txs []*transaction
+}
+
+type transaction struct {
+	tx       *tx.Transaction
+	origin   common.Address
+	gasPayer common.Address
+	receipt  *client.TransactionReceipt
+	included *block
+}
+
+type account struct {
+	client.Account
+	code    []byte
+	storage map[common.Hash]common.Hash
+}
+
+// NewNode starts a fake node with the genesis block of the solo network, so that transactions signed for
+// solo are accepted. The node is closed when the test ends.
+func NewNode(tb testing.TB) *Node {
+	tb.Helper()
+
+	genesis := &block{Block: client.Block{
+		Number:       0,
+		ID:           solo.GenesisID(),
+		Timestamp:    time.Now().Add(-BlockInterval).Unix(),
+		GasLimit:     10_000_000,
+		IsTrunk:      true,
+		Transactions: []common.Hash{},
+	}}
+	n := &Node{
+		tb:       tb,
+		blocks:   []*block{genesis},
+		orphans:  make(map[common.Hash]*block),
+		txs:      make(map[common.Hash]*transaction),
+		receipts: make(map[common.Hash]client.TransactionReceipt),
+		accounts: make(map[common.Address]*account),
+		peers:    []client.Peer{},
+		inspect:  defaultInspect,
+	}
+	n.server = httptest.NewServer(n.handler())
+	tb.Cleanup(n.server.Close)
+	return n
+}
+
+// URL returns the base URL of the node.
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Client creates a client connected to the node. The test fails if the client can't be created.
+func (n *Node) Client(opts ...client.Option) *client.Client {
+	n.tb.Helper()
+	c, err := client.New(n.server.URL, n.server.Client(), opts...)
+	if err != nil {
+		n.tb.Fatalf("thortest: failed to create client: %v", err)
+	}
+	return c
+}
+
+// Close shuts the node down. It is called automatically when the test ends.
+func (n *Node) Close() {
+	n.server.Close()
+}
+
+// Genesis returns the genesis block.
+func (n *Node) Genesis() client.Block {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.blockView(n.blocks[0])
+}
+
+// Best returns the best block.
+func (n *Node) Best() client.Block {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.blockView(n.blocks[len(n.blocks)-1])
+}
+
+// SetAutoMine makes the node mine a block for every transaction it receives.
+func (n *Node) SetAutoMine(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.autoMine = enabled
+}
+
+// Mine adds a block containing the pending transactions to the chain and returns it.
+func (n *Node) Mine() client.Block {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.blockView(n.mine())
+}
+
+// MineEmpty adds count empty blocks to the chain.
+func (n *Node) MineEmpty(count int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for range count {
+		n.mine()
+	}
+}
+
+// Finalize marks the blocks up to the given number as finalized. It also sets the justified block.
+func (n *Node) Finalize(number int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.finalized = min(number, int64(len(n.blocks)-1))
+}
+
+// Reorg removes the last count blocks from the canonical chain, as when a fork with a higher score wins. The
+// transactions of the removed blocks return to the pending pool, and the removed blocks can still be fetched by
+// ID. The blocks mined afterwards form the new chain. Finalized blocks can't be removed: the test fails and the
+// chain is left unchanged. Reorg may be called from any goroutine, such as a handler driving a stream.
+func (n *Node) Reorg(count int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	best := int64(len(n.blocks) - 1)
+	if int64(count) > best-n.finalized {
+		n.tb.Errorf("thortest: can't remove %d blocks, block %d is finalized", count, n.finalized)
+		return
+	}
+	removed := make(map[common.Hash]bool)
+	var txs []*transaction
+	for _, b := range n.blocks[best-int64(count)+1:] {
+		b.IsTrunk = false
+		n.orphans[b.ID] = b
+		removed[b.ID] = true
+		for _, t := range b.txs {
+			t.receipt = nil
+			t.included = nil
+			txs = append(txs, t)
+		}
+	}
+	n.blocks = n.blocks[:best-int64(count)+1]
+	n.pending = append(txs, n.pending...)
+	n.events = slices.DeleteFunc(n.events, func(ev client.EventLog) bool { return removed[ev.Meta.BlockID] })
+	n.transfers = slices.DeleteFunc(n.transfers, func(tr client.TransferLog) bool { return removed[tr.Meta.BlockID] })
+}
+
+// Pending returns the transactions received by the node which are not yet in a block.
+func (n *Node) Pending() []*tx.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	pending := make([]*tx.Transaction, 0, len(n.pending))
+	for _, t := range n.pending {
+		pending = append(pending, t.tx)
+	}
+	return pending
+}
+
+// SetReceipt sets the receipt of a transaction, for example to make it revert or to emit events.
+// The metadata of the receipt is filled in when the transaction is mined. Events and transfers of the
+// outputs are indexed and returned by the log filters.
+func (n *Node) SetReceipt(id common.Hash, receipt client.TransactionReceipt) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.receipts[id] = receipt
+}
+
+// SetAccount sets the balance and energy of an account.
+func (n *Node) SetAccount(addr common.Address, balance, energy *big.Int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	acc := n.account(addr)
+	acc.Balance = hexutil.Big(*new(big.Int).Set(balance))
+	acc.Energy = hexutil.Big(*new(big.Int).Set(energy))
+}
+
+// SetCode sets the code of an account.
+func (n *Node) SetCode(addr common.Address, code []byte) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	acc := n.account(addr)
+	acc.code = common.CopyBytes(code)
+	acc.HasCode = len(code) > 0
+}
+
+// SetStorage sets a storage slot of an account.
+func (n *Node) SetStorage(addr common.Address, key, value common.Hash) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.account(addr).storage[key] = value
+}
+
+// OnInspect sets the function computing the results of inspections. By default every clause succeeds
+// without output.
+func (n *Node) OnInspect(fn InspectFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.inspect = fn
+}
+
+// SetInspectResults makes every inspection return the given results.
+func (n *Node) SetInspectResults(results ...client.InspectResponse) {
+	n.OnInspect(func(client.InspectRequest, string) ([]client.InspectResponse, error) {
+		return results, nil
+	})
+}
+
+// AddEventLogs adds event logs to the log index, in addition to the events of mined receipts.
+func (n *Node) AddEventLogs(logs ...client.EventLog) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, logs...)
+	sort.SliceStable(n.events, func(i, j int) bool {
+		return n.events[i].Meta.BlockNumber < n.events[j].Meta.BlockNumber
+	})
+}
+
+// AddTransferLogs adds transfer logs to the log index, in addition to the transfers of mined receipts.
+func (n *Node) AddTransferLogs(logs ...client.TransferLog) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.transfers = append(n.transfers, logs...)
+	sort.SliceStable(n.transfers, func(i, j int) bool {
+		return n.transfers[i].Meta.BlockNumber < n.transfers[j].Meta.BlockNumber
+	})
+}
+
+// SetPeers sets the peers reported by the node.
+func (n *Node) SetPeers(peers ...client.Peer) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.peers = append([]client.Peer{}, peers...)
+}
+
+// InjectFault makes the node answer matching requests with an error. Faults are checked in the order
+// they were injected.
+func (n *Node) InjectFault(fault Fault) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.faults = append(n.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (n *Node) ClearFaults() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.faults = nil
+}
+
+// fault returns the first fault matching the request and consumes one of its occurrences.
+func (n *Node) fault(method, path string) *Fault {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, f := range n.faults {
+		if f.Method != "" && f.Method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				n.faults = append(n.faults[:i], n.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (n *Node) account(addr common.Address) *account {
+	acc, ok := n.accounts[addr]
+	if !ok {
+		acc = &account{storage: make(map[common.Hash]common.Hash)}
+		n.accounts[addr] = acc
+	}
+	return acc
+}
+
+func (n *Node) mine() *block {
+	parent := n.blocks[len(n.blocks)-1]
+	number := parent.Number + 1
+	id := memchain.BlockID(parent.ID, number)
+	for n.orphans[id] != nil {
+		// mining again after a reorg: the new block must differ from the orphaned one
+		id = memchain.BlockID(id, number)
+	}
+	b := &block{Block: client.Block{
+		Number:       number,
+		ID:           id,
+		ParentID:     parent.ID,
+		Timestamp:    parent.Timestamp + int64(BlockInterval/time.Second),
+		GasLimit:     parent.GasLimit,
+		TotalScore:   number,
+		IsTrunk:      true,
+		Transactions: []common.Hash{},
+	}}
+
+	for _, t := range n.pending {
+		id := t.tx.ID()
+		receipt, ok := n.receipts[id]
+		if !ok {
+			receipt = defaultReceipt(t.tx, t.gasPayer)
+		}
+		receipt.Meta = client.ReceiptMeta{
+			BlockID:        b.ID,
+			BlockNumber:    b.Number,
+			BlockTimestamp: b.Timestamp,
+			TxID:           id,
+			TxOrigin:       t.origin,
+		}
+		t.receipt = &receipt
+		t.included = b
+		b.txs = append(b.txs, t)
+		b.Transactions = append(b.Transactions, id)
+		b.GasUsed += receipt.GasUsed
+		n.events, n.transfers = memchain.Index(n.events, n.transfers, &receipt, int64(len(b.txs)-1))
+	}
+	n.pending = nil
+	n.blocks = append(n.blocks, b)
+	return b
+}
+
+// blockView returns a copy of the block as served by the node.
+func (n *Node) blockView(b *block) client.Block {
+	view := b.Block
+	view.Transactions = append([]common.Hash{}, b.Transactions...)
+	view.IsFinalized = b.Number <= n.finalized
+	return view
+}
+
+// defaultReceipt is the receipt of a successful transaction using all of its gas without output.
+func defaultReceipt(trx *tx.Transaction, gasPayer common.Address) client.TransactionReceipt {
+	outputs := make([]client.Output, len(trx.Clauses()))
+	for i := range outputs {
+		outputs[i] = client.Output{Events: []client.Event{}, Transfers: []client.Transfer{}}
+	}
+	return client.TransactionReceipt{
+		GasUsed:  int64(trx.Gas()),
+		GasPayer: gasPayer,
+		Paid:     (*hexutil.Big)(new(big.Int)),
+		Reward:   (*hexutil.Big)(new(big.Int)),
+		Outputs:  outputs,
+	}
+}
+
+func defaultInspect(req client.InspectRequest, _ string) ([]client.InspectResponse, error) {
+	results := make([]client.InspectResponse, len(req.Clauses))
+	for i := range results {
+		results[i] = client.InspectResponse{Data: "0x", Events: []client.Event{}, Transfers: []client.Transfer{}}
+	}
+	return results, nil
+}
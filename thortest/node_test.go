@@ -0,0 +1,210 @@
+package thortest_test
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/darrenvechain/thorgo"
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/crypto/tx"
+	"github.com/darrenvechain/thorgo/events"
+	"github.com/darrenvechain/thorgo/solo"
+	"github.com/darrenvechain/thorgo/thortest"
+	"github.com/darrenvechain/thorgo/txmanager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_Blocks(t *testing.T) {
+	node := thortest.NewNode(t)
+	c := node.Client()
+	assert.Equal(t, solo.ChainTag(), c.ChainTag())
+
+	node.MineEmpty(3)
+	node.Finalize(2)
+
+	best, err := c.BestBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), best.Number)
+	assert.Equal(t, node.Best().ID, best.ID)
+
+	byID, err := c.Block(client.RevisionID(best.ParentID))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), byID.Number)
+	assert.True(t, byID.IsFinalized)
+
+	finalized, err := c.Block(client.RevisionFinalized)
+	assert.NoError(t, err)
+	assert.Equal(t, byID.ID, finalized.ID)
+
+	_, err = c.Block(client.RevisionNumber(100))
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	_, err = c.Block(client.RevisionNext)
+	assert.ErrorIs(t, err, client.ErrInvalidRevision)
+}
+
+func TestNode_Transactions(t *testing.T) {
+	node := thortest.NewNode(t)
+	thor := thorgo.FromClient(node.Client())
+	sender := txmanager.FromPK(solo.Keys()[0], thor)
+	recipient := common.HexToAddress("0x1234")
+
+	event := client.Event{Address: recipient, Topics: []common.Hash{common.HexToHash("0x01")}, Data: "0x"}
+	id, err := sender.SendClauses([]*tx.Clause{tx.NewClause(&recipient).WithValue(big.NewInt(1))})
+	assert.NoError(t, err)
+	node.SetReceipt(id, client.TransactionReceipt{Outputs: []client.Output{{Events: []client.Event{event}}}})
+
+	pending, err := thor.Client.PendingTransactionWithContext(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, sender.Address(), pending.Origin)
+	_, err = thor.Transaction(id).Receipt()
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	block := node.Mine()
+	receipt, err := thor.Transaction(id).Receipt()
+	assert.NoError(t, err)
+	assert.Equal(t, block.ID, receipt.Meta.BlockID)
+	assert.Equal(t, sender.Address(), receipt.Meta.TxOrigin)
+
+	expanded, err := thor.Blocks.Expanded(client.RevisionID(block.ID))
+	assert.NoError(t, err)
+	assert.Len(t, expanded.Transactions, 1)
+	assert.Equal(t, id, expanded.Transactions[0].ID)
+
+	logs, err := thor.Events([]client.EventCriteria{{Address: &recipient}}).Apply(0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, id, logs[0].Meta.TxID)
+
+	signed, err := thor.Client.RawTransactionWithContext(context.Background(), id)
+	assert.NoError(t, err)
+	_, err = thor.Client.SendRawTransactionWithContext(context.Background(), signed.Raw)
+	assert.ErrorIs(t, err, client.ErrKnownTx)
+}
+
+func TestNode_AccountsAndInspect(t *testing.T) {
+	node := thortest.NewNode(t)
+	c := node.Client()
+	addr := common.HexToAddress("0x1234")
+
+	node.SetAccount(addr, big.NewInt(100), big.NewInt(200))
+	node.SetCode(addr, []byte{0x60, 0x80})
+	node.SetStorage(addr, common.Hash{}, common.HexToHash("0x2a"))
+
+	acc, err := c.Account(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), acc.Balance.ToInt().Int64())
+	assert.True(t, acc.HasCode)
+
+	code, err := c.AccountCode(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x6080", code.Code)
+
+	storage, err := c.AccountStorage(addr, common.Hash{})
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x2a").Hex(), storage.Value)
+
+	node.SetInspectResults(client.InspectResponse{Data: "0x01", Reverted: true, VmError: "execution reverted"})
+	res, err := c.Inspect(client.InspectRequest{Clauses: []*tx.Clause{tx.NewClause(&addr)}})
+	assert.NoError(t, err)
+	assert.True(t, res[0].Reverted)
+}
+
+func TestNode_Faults(t *testing.T) {
+	node := thortest.NewNode(t)
+	c := node.Client(client.WithRetry(client.RetryPolicy{MaxAttempts: 2}))
+
+	node.InjectFault(thortest.Fault{Path: "/blocks/best", Status: 503, Body: "unavailable", Times: 1})
+	_, err := c.BestBlock()
+	assert.NoError(t, err)
+
+	node.InjectFault(thortest.Fault{Method: "POST", Path: "/transactions", Status: 403, Body: "tx rejected: pool is full"})
+	_, err = c.SendRawTransaction("0x00")
+	assert.ErrorIs(t, err, client.ErrTxPoolFull)
+
+	node.ClearFaults()
+	_, err = c.SendRawTransaction("0x00")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, client.ErrTxPoolFull)
+}
+
+func TestNode_Reorg(t *testing.T) {
+	node := thortest.NewNode(t)
+	c := node.Client()
+	node.MineEmpty(3)
+	orphan := node.Best()
+
+	node.Reorg(2)
+	assert.Equal(t, int64(1), node.Best().Number)
+	node.MineEmpty(2)
+
+	best, err := c.BestBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), best.Number)
+	assert.NotEqual(t, orphan.ID, best.ID)
+
+	byID, err := c.Block(client.RevisionID(orphan.ID))
+	assert.NoError(t, err)
+	assert.False(t, byID.IsTrunk)
+}
+
+// errorRecorder records the errors reported to a test instead of failing it.
+type errorRecorder struct {
+	testing.TB
+	errors atomic.Int32
+}
+
+func (r *errorRecorder) Errorf(string, ...interface{}) {
+	r.errors.Add(1)
+}
+
+func TestNode_ReorgFinalized(t *testing.T) {
+	recorder := &errorRecorder{TB: t}
+	node := thortest.NewNode(recorder)
+	node.MineEmpty(3)
+	node.Finalize(2)
+
+	// the test fails from another goroutine, and the chain is unchanged
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		node.Reorg(2)
+	}()
+	<-done
+	assert.Equal(t, int32(1), recorder.errors.Load())
+	assert.Equal(t, int64(3), node.Best().Number)
+}
+
+func TestNode_LogIndexes(t *testing.T) {
+	node := thortest.NewNode(t)
+	thor := thorgo.FromClient(node.Client())
+	addr := common.HexToAddress("0x1234")
+	event := client.Event{Address: addr, Topics: []common.Hash{{1}}, Data: "0x"}
+
+	for i := range 2 {
+		sender := txmanager.FromPK(solo.Keys()[i], thor)
+		id, err := sender.SendClauses([]*tx.Clause{tx.NewClause(&addr), tx.NewClause(&addr)})
+		assert.NoError(t, err)
+		node.SetReceipt(id, client.TransactionReceipt{Outputs: []client.Output{
+			{Events: []client.Event{event}},
+			{Events: []client.Event{event, event}},
+		}})
+	}
+	node.Mine()
+
+	logs, err := thor.Events(nil).IncludeIndexes().Iterator(context.Background(), events.IteratorOptions{}).All()
+	assert.NoError(t, err)
+	assert.Len(t, logs, 6)
+	for i, log := range logs {
+		assert.Equal(t, int64(i/3), *log.Meta.TxIndex)
+		assert.Equal(t, int64(i), *log.Meta.LogIndex)
+	}
+
+	logs, err = thor.Events(nil).Apply(0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 6)
+	assert.Nil(t, logs[0].Meta.TxIndex)
+	assert.Nil(t, logs[0].Meta.LogIndex)
+}
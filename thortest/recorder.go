@@ -0,0 +1,275 @@
+package thortest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ErrUnexpectedRequest is returned by a replaying Recorder for requests which were not recorded.
+var ErrUnexpectedRequest = errors.New("thortest: unexpected request")
+
+// RecorderMode selects whether a Recorder talks to a real node or replays a golden file.
+type RecorderMode int
+
+const (
+	// Replay serves the interactions of the golden file and never touches the network.
+	Replay RecorderMode = iota
+	// Record forwards requests to the node and saves the interactions to the golden file.
+	Record
+)
+
+// RecorderOptions configures a Recorder.
+type RecorderOptions struct {
+	Mode RecorderMode
+	// Strict makes replay fail when a request is sent more times than it was recorded, and makes Verify
+	// report recorded interactions which were not replayed.
+	Strict bool
+	// Transport sends the requests in Record mode. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// Interaction is a request and its response, as stored in a golden file.
+type Interaction struct {
+	Method      string          `json:"method"`
+	URI         string          `json:"uri"`
+	Request     json.RawMessage `json:"request,omitempty"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"contentType,omitempty"`
+	// Response holds JSON responses, ResponseText the other ones, such as error messages.
+	Response     json.RawMessage `json:"response,omitempty"`
+	ResponseText string          `json:"responseText,omitempty"`
+
+	used int
+}
+
+// Recorder is an http.RoundTripper which records the interactions with a node to a golden file, or replays them.
+//
+// Requests are matched on their method, path with query and body. The host is ignored, so a recording made
+// against one node can be replayed whatever the URL given to the client. Identical requests, for example
+// polling the best block, are answered with the recorded responses in order; once exhausted, the last response
+// is repeated, unless the recorder is strict.
+type Recorder struct {
+	path string
+	opts RecorderOptions
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	unexpected   []string
+}
+
+// NewRecorder creates a recorder for the given golden file. In Replay mode the file must exist.
+func NewRecorder(path string, opts RecorderOptions) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, opts: opts}
+	if opts.Mode == Record {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("thortest: invalid golden file %s: %w", path, err)
+	}
+	// the golden file is indented, requests are matched on compact JSON
+	for _, in := range r.interactions {
+		if in.Request != nil {
+			in.Request = compact(in.Request)
+		}
+	}
+	return r, nil
+}
+
+// UseRecorder creates a recorder for a test. The golden file is saved when the test ends in Record mode,
+// and the test fails on unexpected or, if strict, unused interactions in Replay mode.
+func UseRecorder(tb testing.TB, path string, opts RecorderOptions) *Recorder {
+	tb.Helper()
+	r, err := NewRecorder(path, opts)
+	if err != nil {
+		tb.Fatalf("thortest: %v", err)
+	}
+	tb.Cleanup(func() {
+		if opts.Mode == Record {
+			if err := r.Save(); err != nil {
+				tb.Errorf("thortest: %v", err)
+			}
+			return
+		}
+		if err := r.Verify(); err != nil {
+			tb.Error(err)
+		}
+	})
+	return r
+}
+
+// Client returns an HTTP client using the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.opts.Mode == Record {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	res, err := r.opts.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &Interaction{
+		Method:      req.Method,
+		URI:         req.URL.RequestURI(),
+		Request:     toJSON(body),
+		Status:      res.StatusCode,
+		ContentType: res.Header.Get("Content-Type"),
+	}
+	if json.Valid(resBody) {
+		in.Response = compact(resBody)
+	} else {
+		in.ResponseText = string(resBody)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, in)
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request := toJSON(body)
+	var match *Interaction
+	for _, in := range r.interactions {
+		if in.Method != req.Method || in.URI != req.URL.RequestURI() || !bytes.Equal(in.Request, request) {
+			continue
+		}
+		match = in
+		if in.used == 0 {
+			break
+		}
+	}
+	if match == nil || (match.used > 0 && r.opts.Strict) {
+		call := req.Method + " " + req.URL.RequestURI()
+		r.unexpected = append(r.unexpected, call)
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedRequest, call)
+	}
+	match.used++
+
+	header := make(http.Header)
+	if match.ContentType != "" {
+		header.Set("Content-Type", match.ContentType)
+	}
+	response := []byte(match.ResponseText)
+	if match.Response != nil {
+		response = match.Response
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Status, http.StatusText(match.Status)),
+		StatusCode:    match.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(response)),
+		ContentLength: -1,
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the golden file, creating its directory if needed.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// Verify reports the requests which had no recorded interaction and, if strict, the interactions
+// which were never replayed.
+func (r *Recorder) Verify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var problems []string
+	for _, call := range r.unexpected {
+		problems = append(problems, "unexpected request "+call)
+	}
+	if r.opts.Strict {
+		for _, in := range r.interactions {
+			if in.used == 0 {
+				problems = append(problems, "unused interaction "+in.Method+" "+in.URI)
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("thortest: %s: %s", r.path, strings.Join(problems, ", "))
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// toJSON stores a request body as compact JSON, so that golden files are readable and requests match whatever
+// their formatting. Bodies which are not JSON are stored as JSON strings.
+func toJSON(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return compact(body)
+	}
+	encoded, _ := json.Marshal(string(body))
+	return encoded
+}
+
+func compact(body []byte) json.RawMessage {
+	var buf bytes.Buffer
+	_ = json.Compact(&buf, body)
+	return buf.Bytes()
+}
@@ -0,0 +1,73 @@
+package thortest_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/darrenvechain/thorgo/client"
+	"github.com/darrenvechain/thorgo/thortest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "recording.json")
+	addr := common.HexToAddress("0x1234")
+	calls := func(c *client.Client) {
+		_, err := c.BestBlock()
+		assert.NoError(t, err)
+		_, err = c.Account(addr)
+		assert.NoError(t, err)
+		_, err = c.TransactionReceipt(common.Hash{1})
+		assert.ErrorIs(t, err, client.ErrNotFound)
+		_, err = c.Block(client.RevisionNext)
+		assert.ErrorIs(t, err, client.ErrInvalidRevision)
+		_, err = c.FilterEvents(&client.EventFilter{Criteria: &[]client.EventCriteria{{Address: &addr}}})
+		assert.NoError(t, err)
+	}
+
+	// record against a node
+	node := thortest.NewNode(t)
+	node.MineEmpty(2)
+	recorder, err := thortest.NewRecorder(golden, thortest.RecorderOptions{Mode: thortest.Record})
+	assert.NoError(t, err)
+	c, err := client.New(node.URL(), recorder.Client())
+	assert.NoError(t, err)
+	calls(c)
+	assert.NoError(t, recorder.Save())
+	node.Close()
+
+	// replay without the node
+	replay, err := thortest.NewRecorder(golden, thortest.RecorderOptions{Strict: true})
+	assert.NoError(t, err)
+	c, err = client.New("http://unreachable.invalid", replay.Client())
+	assert.NoError(t, err)
+	calls(c)
+
+	best, err := c.BestBlock()
+	assert.Nil(t, best)
+	assert.ErrorIs(t, err, thortest.ErrUnexpectedRequest)
+	assert.ErrorContains(t, replay.Verify(), "unexpected request GET /blocks/best")
+}
+
+func TestRecorder_Unused(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "recording.json")
+	node := thortest.NewNode(t)
+	recorder := thortest.UseRecorder(t, golden, thortest.RecorderOptions{Mode: thortest.Record})
+	c, err := client.New(node.URL(), recorder.Client())
+	assert.NoError(t, err)
+	_, err = c.Peers()
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	// lenient replays only report unexpected requests
+	replay := thortest.UseRecorder(t, golden, thortest.RecorderOptions{})
+	_, err = client.New(node.URL(), replay.Client())
+	assert.NoError(t, err)
+
+	strict, err := thortest.NewRecorder(golden, thortest.RecorderOptions{Strict: true})
+	assert.NoError(t, err)
+	_, err = client.New(node.URL(), strict.Client())
+	assert.NoError(t, err)
+	assert.ErrorContains(t, strict.Verify(), "unused interaction GET /node/network/peers")
+}
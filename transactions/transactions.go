@@ -1,6 +1,8 @@
 package transactions
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,12 +12,12 @@ import (
 )
 
 type Visitor struct {
-	client *client.Client
+	client client.Backend
 	hash   common.Hash
 	blocks *blocks.Blocks
 }
 
-func New(client *client.Client, hash common.Hash) *Visitor {
+func New(client client.Backend, hash common.Hash) *Visitor {
 	return &Visitor{client: client, hash: hash, blocks: blocks.New(client)}
 }
 
@@ -25,22 +27,42 @@ func (v *Visitor) ID() common.Hash {
 
 // Get fetches the transaction by its hash. This includes the clauses, but not the outputs.
 func (v *Visitor) Get() (*client.Transaction, error) {
-	return v.client.Transaction(v.hash)
+	return v.GetWithContext(context.Background())
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (v *Visitor) GetWithContext(ctx context.Context) (*client.Transaction, error) {
+	return v.client.TransactionWithContext(ctx, v.hash)
 }
 
 // Receipt fetches the transaction receipt by its hash. This includes the outputs.
 func (v *Visitor) Receipt() (*client.TransactionReceipt, error) {
-	return v.client.TransactionReceipt(v.hash)
+	return v.ReceiptWithContext(context.Background())
+}
+
+// ReceiptWithContext is like Receipt but uses the given context for the request.
+func (v *Visitor) ReceiptWithContext(ctx context.Context) (*client.TransactionReceipt, error) {
+	return v.client.TransactionReceiptWithContext(ctx, v.hash)
 }
 
 // Raw fetches the raw transaction by its hash.
 func (v *Visitor) Raw() (*client.RawTransaction, error) {
-	return v.client.RawTransaction(v.hash)
+	return v.RawWithContext(context.Background())
+}
+
+// RawWithContext is like Raw but uses the given context for the request.
+func (v *Visitor) RawWithContext(ctx context.Context) (*client.RawTransaction, error) {
+	return v.client.RawTransactionWithContext(ctx, v.hash)
 }
 
 // Pending includes the transaction in the pending pool when querying for a transaction.
 func (v *Visitor) Pending() (*client.Transaction, error) {
-	return v.client.PendingTransaction(v.hash)
+	return v.PendingWithContext(context.Background())
+}
+
+// PendingWithContext is like Pending but uses the given context for the request.
+func (v *Visitor) PendingWithContext(ctx context.Context) (*client.Transaction, error) {
+	return v.client.PendingTransactionWithContext(ctx, v.hash)
 }
 
 // Wait for the transaction to be included in a block.
@@ -53,23 +75,38 @@ func (v *Visitor) Wait() (*client.TransactionReceipt, error) {
 // It will wait for the given duration.
 // If the transaction is not included in a block within the duration, it will return an error.
 func (v *Visitor) WaitFor(duration time.Duration) (*client.TransactionReceipt, error) {
-	receipt, err := v.client.TransactionReceipt(v.hash)
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	receipt, err := v.WaitWithContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out waiting for the tx receipt %s", v.hash.String())
+	}
+	return receipt, err
+}
+
+// WaitWithContext waits for the transaction to be included in a block until the context is done.
+// Use context.WithTimeout or context.WithDeadline to bound the wait.
+func (v *Visitor) WaitWithContext(ctx context.Context) (*client.TransactionReceipt, error) {
+	receipt, err := v.client.TransactionReceiptWithContext(ctx, v.hash)
 	if err == nil {
 		return receipt, nil
 	}
 
-	timeout := time.After(duration)
-
 	for {
 		select {
-		case <-timeout:
-			return nil, fmt.Errorf("timed out waiting for the tx receipt %s", v.hash.String())
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for the tx receipt %s: %w", v.hash.String(), ctx.Err())
 		default:
-			_, err := v.blocks.Ticker()
+			_, err := v.blocks.TickerWithContext(ctx)
 			if err != nil {
-				time.Sleep(1 * time.Second)
+				select {
+				case <-ctx.Done():
+					continue
+				case <-time.After(1 * time.Second):
+				}
 			}
-			receipt, err = v.client.TransactionReceipt(v.hash)
+			receipt, err = v.client.TransactionReceiptWithContext(ctx, v.hash)
 			if err == nil {
 				return receipt, nil
 			}
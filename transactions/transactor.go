@@ -1,6 +1,7 @@
 package transactions
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -11,13 +12,13 @@ import (
 
 // Transactor is a transaction builder that can be used to simulate, build and send transactions.
 type Transactor struct {
-	client   *client.Client
+	client   client.Backend
 	clauses  []*tx.Clause
 	builder  *tx.Builder
 	gasPayer *common.Address
 }
 
-func NewTransactor(client *client.Client, clauses []*tx.Clause) *Transactor {
+func NewTransactor(client client.Backend, clauses []*tx.Clause) *Transactor {
 	builder := new(tx.Builder)
 	return &Transactor{
 		client:  client,
@@ -76,6 +77,11 @@ func (t *Transactor) Delegate() *Transactor {
 
 // Simulate estimates the gas usage and checks for errors or reversion in the transaction.
 func (t *Transactor) Simulate(caller common.Address) (Simulation, error) {
+	return t.SimulateWithContext(context.Background(), caller)
+}
+
+// SimulateWithContext is like Simulate but uses the given context for the request.
+func (t *Transactor) SimulateWithContext(ctx context.Context, caller common.Address) (Simulation, error) {
 	request := client.InspectRequest{
 		Clauses: t.clauses,
 		Caller:  &caller,
@@ -85,7 +91,7 @@ func (t *Transactor) Simulate(caller common.Address) (Simulation, error) {
 		request.GasPayer = t.gasPayer
 	}
 
-	response, err := t.client.Inspect(request)
+	response, err := t.client.InspectWithContext(ctx, request)
 	if err != nil {
 		return Simulation{}, err
 	}
@@ -117,6 +123,11 @@ func (t *Transactor) Simulate(caller common.Address) (Simulation, error) {
 
 // Build constructs the transaction, applying defaults where necessary.
 func (t *Transactor) Build(caller common.Address) (*tx.Transaction, error) {
+	return t.BuildWithContext(context.Background(), caller)
+}
+
+// BuildWithContext is like Build but uses the given context for any requests made to the node.
+func (t *Transactor) BuildWithContext(ctx context.Context, caller common.Address) (*tx.Transaction, error) {
 	initial := t.builder.Build()
 	chainTag := t.client.ChainTag()
 
@@ -136,7 +147,7 @@ func (t *Transactor) Build(caller common.Address) (*tx.Transaction, error) {
 
 	// Check if gas is set
 	if initial.Gas() == 0 {
-		simulation, err := t.Simulate(caller)
+		simulation, err := t.SimulateWithContext(ctx, caller)
 		if err != nil {
 			return nil, err
 		}
@@ -145,7 +156,7 @@ func (t *Transactor) Build(caller common.Address) (*tx.Transaction, error) {
 
 	// Check if block reference is set
 	if initial.BlockRef().Number() == 0 {
-		best, err := t.client.BestBlock()
+		best, err := t.client.BestBlockWithContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -172,7 +183,12 @@ type Signer interface {
 
 // Send will submit the transaction to the network.
 func (t *Transactor) Send(signer Signer) (*Visitor, error) {
-	tx, err := t.Build(signer.Address())
+	return t.SendWithContext(context.Background(), signer)
+}
+
+// SendWithContext is like Send but uses the given context for any requests made to the node.
+func (t *Transactor) SendWithContext(ctx context.Context, signer Signer) (*Visitor, error) {
+	tx, err := t.BuildWithContext(ctx, signer.Address())
 	if err != nil {
 		return nil, fmt.Errorf("failed to build transaction: %w", err)
 	}
@@ -183,7 +199,7 @@ func (t *Transactor) Send(signer Signer) (*Visitor, error) {
 	}
 	tx = tx.WithSignature(signature)
 
-	res, err := t.client.SendTransaction(tx)
+	res, err := t.client.SendTransactionWithContext(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send transaction: %w", err)
 	}
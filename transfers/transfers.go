@@ -1,6 +1,7 @@
 package transfers
 
 import (
+	"context"
 	"errors"
 
 	"github.com/darrenvechain/thorgo/client"
@@ -13,12 +14,16 @@ var (
 	block      = "block"
 )
 
+// maxLimit is the maximum limit of a transfer log request.
+const maxLimit = 256
+
 type Filter struct {
-	client  *client.Client
-	request *client.TransferFilter
+	client         client.Backend
+	request        *client.TransferFilter
+	includeIndexes *bool
 }
 
-func New(c *client.Client, criteria []client.TransferCriteria) *Filter {
+func New(c client.Backend, criteria []client.TransferCriteria) *Filter {
 	return &Filter{client: c, request: &client.TransferFilter{
 		Criteria: &criteria,
 	}}
@@ -36,6 +41,14 @@ func (f *Filter) Asc() *Filter {
 	return f
 }
 
+// IncludeIndexes asks the node to set the transaction and log indexes in the metadata of the transfers.
+// Nodes which don't support it leave them nil.
+func (f *Filter) IncludeIndexes() *Filter {
+	include := true
+	f.includeIndexes = &include
+	return f
+}
+
 // BlockRange sets the block range for the transfer filter.
 func (f *Filter) BlockRange(from int64, to int64) *Filter {
 	f.request.Range = &client.FilterRange{
@@ -58,13 +71,19 @@ func (f *Filter) TimeRange(from int64, to int64) *Filter {
 
 // Apply sends the transfer filter to the node and returns the results.
 func (f *Filter) Apply(offset int64, limit int64) ([]client.TransferLog, error) {
-	if limit > 256 {
+	return f.ApplyWithContext(context.Background(), offset, limit)
+}
+
+// ApplyWithContext is like Apply but uses the given context for the request.
+func (f *Filter) ApplyWithContext(ctx context.Context, offset int64, limit int64) ([]client.TransferLog, error) {
+	if limit > maxLimit {
 		return nil, errors.New("limit must be less than or equal to 256")
 	}
 	f.request.Options = &client.FilterOptions{
-		Offset: &offset,
-		Limit:  &limit,
+		Offset:         &offset,
+		Limit:          &limit,
+		IncludeIndexes: f.includeIndexes,
 	}
 
-	return f.client.FilterTransfers(f.request)
+	return f.client.FilterTransfersWithContext(ctx, f.request)
 }
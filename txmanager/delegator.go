@@ -2,6 +2,7 @@ package txmanager
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
@@ -31,11 +32,20 @@ func NewDelegatedManager(thor *thorgo.Thor, origin transactions.Signer, gasPayer
 }
 
 func (d *DelegatedManager) SignTransaction(tx *tx.Transaction) ([]byte, error) {
+	return d.signTransaction(context.Background(), tx)
+}
+
+func (d *DelegatedManager) signTransaction(ctx context.Context, tx *tx.Transaction) ([]byte, error) {
 	signature, err := d.origin.SignTransaction(tx)
 	if err != nil {
 		return nil, err
 	}
-	delegatorSig, err := d.gasPayer.Delegate(tx, d.Address())
+	var delegatorSig []byte
+	if delegator, ok := d.gasPayer.(ContextDelegator); ok {
+		delegatorSig, err = delegator.DelegateWithContext(ctx, tx, d.Address())
+	} else {
+		delegatorSig, err = d.gasPayer.Delegate(tx, d.Address())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -44,16 +54,21 @@ func (d *DelegatedManager) SignTransaction(tx *tx.Transaction) ([]byte, error) {
 }
 
 func (d *DelegatedManager) SendClauses(clauses []*tx.Clause) (common.Hash, error) {
-	tx, err := d.thor.Transactor(clauses).Delegate().Build(d.Address())
+	return d.SendClausesWithContext(context.Background(), clauses)
+}
+
+// SendClausesWithContext is like SendClauses but uses the given context for the node and delegator requests.
+func (d *DelegatedManager) SendClausesWithContext(ctx context.Context, clauses []*tx.Clause) (common.Hash, error) {
+	tx, err := d.thor.Transactor(clauses).Delegate().BuildWithContext(ctx, d.Address())
 	if err != nil {
 		return common.Hash{}, err
 	}
-	signature, err := d.SignTransaction(tx)
+	signature, err := d.signTransaction(ctx, tx)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 	tx = tx.WithSignature(signature)
-	res, err := d.thor.Client.SendTransaction(tx)
+	res, err := d.thor.Backend.SendTransactionWithContext(ctx, tx)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
@@ -96,6 +111,11 @@ func NewUrlDelegator(url string) *URLDelegator {
 }
 
 func (p *URLDelegator) Delegate(tx *tx.Transaction, origin common.Address) ([]byte, error) {
+	return p.DelegateWithContext(context.Background(), tx, origin)
+}
+
+// DelegateWithContext is like Delegate but uses the given context for the request to the delegator.
+func (p *URLDelegator) DelegateWithContext(ctx context.Context, tx *tx.Transaction, origin common.Address) ([]byte, error) {
 	encoded, err := tx.Encoded()
 	if err != nil {
 		return nil, err
@@ -111,7 +131,13 @@ func (p *URLDelegator) Delegate(tx *tx.Transaction, origin common.Address) ([]by
 		return nil, err
 	}
 
-	res, err := http.Post(p.url, "application/json", bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(request)
 	if err != nil {
 		return nil, err
 	}
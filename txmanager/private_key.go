@@ -1,6 +1,7 @@
 package txmanager
 
 import (
+	"context"
 	"crypto/ecdsa"
 
 	"github.com/darrenvechain/thorgo"
@@ -36,7 +37,12 @@ func (p *PKManager) PublicKey() *ecdsa.PublicKey {
 }
 
 func (p *PKManager) SendClauses(clauses []*tx.Clause) (common.Hash, error) {
-	tx, err := p.thor.Transactor(clauses).Build(p.Address())
+	return p.SendClausesWithContext(context.Background(), clauses)
+}
+
+// SendClausesWithContext is like SendClauses but uses the given context for any requests made to the node.
+func (p *PKManager) SendClausesWithContext(ctx context.Context, clauses []*tx.Clause) (common.Hash, error) {
+	tx, err := p.thor.Transactor(clauses).BuildWithContext(ctx, p.Address())
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -44,7 +50,7 @@ func (p *PKManager) SendClauses(clauses []*tx.Clause) (common.Hash, error) {
 	if err != nil {
 		return common.Hash{}, err
 	}
-	res, err := p.thor.Client.SendTransaction(tx.WithSignature(signature))
+	res, err := p.thor.Backend.SendTransactionWithContext(ctx, tx.WithSignature(signature))
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -21,6 +21,8 @@ var (
 	_ accounts.TxManager = &txmanager.PKManager{}
 	// PKManager should implement transactions.Signer
 	_ transactions.Signer = &txmanager.PKManager{}
+	// PKManager should implement accounts.ContextTxManager
+	_ accounts.ContextTxManager = &txmanager.PKManager{}
 )
 
 // TestPKSigner demonstrates ease the ease of sending a transaction using a private key signer
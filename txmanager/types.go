@@ -1,6 +1,8 @@
 package txmanager
 
 import (
+	"context"
+
 	"github.com/darrenvechain/thorgo/crypto/tx"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -10,6 +12,12 @@ type Delegator interface {
 	Delegate(tx *tx.Transaction, origin common.Address) ([]byte, error)
 }
 
+// ContextDelegator is a Delegator that can abort the delegation when a context is done.
+type ContextDelegator interface {
+	Delegator
+	DelegateWithContext(ctx context.Context, tx *tx.Transaction, origin common.Address) ([]byte, error)
+}
+
 type DelegateRequest struct {
 	Origin string `json:"origin"`
 	Raw    string `json:"raw"`